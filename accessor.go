@@ -0,0 +1,104 @@
+package convertor
+
+import (
+	"reflect"
+	"strings"
+)
+
+// OptionAccessorMethods use getter methods like GetName() string as readable fields of src struct,
+// and setter methods like SetName(string) or SetName(string) error as writable fields of dest struct,
+// methods of pointer type are included, struct fields are prior to methods with the same name
+func OptionAccessorMethods() Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().accessors = true
+		return nil
+	}
+}
+
+// accessorField return field of getter method for src struct or setter method for dest struct
+func (cfg *structConfig) accessorField(method reflect.Method) (typeField, bool) {
+	typ := method.Type // the first in is receiver
+	var name string
+	var fieldType reflect.Type
+	var ok bool
+	if cfg.dest {
+		name, ok = strings.CutPrefix(method.Name, "Set")
+		if !ok || typ.NumIn() != 2 || typ.NumOut() > 1 || (typ.NumOut() == 1 && !isErrorType(typ.Out(0))) {
+			return typeField{}, false
+		}
+		fieldType = typ.In(1)
+	} else {
+		name, ok = strings.CutPrefix(method.Name, "Get")
+		if !ok || typ.NumIn() != 1 || typ.NumOut() != 1 {
+			return typeField{}, false
+		}
+		fieldType = typ.Out(0)
+	}
+	if name == "" {
+		return typeField{}, false
+	}
+	return typeField{
+		Type:     fieldType,
+		Name:     cfg.fieldName(cfg.goFieldName(name)),
+		Idx:      method.Index,
+		NextIdx:  -1,
+		Origin:   method.Name,
+		Accessor: true,
+	}, true
+}
+
+// promotedMethods return names of methods promoted from anonymous fields,
+// they are called by the path of flatten field, so a nil embedded pointer is allocated
+func promotedMethods(typ reflect.Type) map[string]bool {
+	promoted := map[string]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		ptrType := field.Type
+		if ptrType.Kind() != reflect.Ptr {
+			ptrType = reflect.PtrTo(ptrType)
+		}
+		for k := 0; k < ptrType.NumMethod(); k++ {
+			promoted[ptrType.Method(k).Name] = true
+		}
+	}
+	return promoted
+}
+
+// callGetter call getter method of pointer type, val is copied if it's not addressable
+func callGetter(val reflect.Value, idx int) (reflect.Value, error) {
+	if !val.CanAddr() {
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		val = ptr.Elem()
+	}
+	out, err := callFunc(val.Addr().Method(idx), nil)
+	if err != nil {
+		return zeroValue, err
+	}
+	return out[0], nil
+}
+
+func noopSet() error {
+	return nil
+}
+
+// setterDest return a temporary dest value for setter method, set passes it to the setter
+func setterDest(dest reflect.Value, field typeField) (reflect.Value, func() error) {
+	setter := dest.Addr().Method(field.Idx)
+	tmp := reflect.New(field.Type).Elem()
+	return tmp, func() error {
+		out, err := callFunc(setter, []reflect.Value{tmp})
+		if err != nil {
+			return err
+		}
+		if len(out) > 0 {
+			if err, ok := out[0].Interface().(error); ok {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package convertor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AccessorUser struct {
+	name  string
+	age   int
+	Email string
+}
+
+func (u *AccessorUser) GetName() string {
+	return u.name
+}
+
+func (u *AccessorUser) SetName(name string) {
+	u.name = name
+}
+
+func (u AccessorUser) GetAge() int {
+	return u.age
+}
+
+func (u *AccessorUser) SetAge(age int) error {
+	if age < 0 {
+		return errors.New("negative age")
+	}
+	u.age = age
+	return nil
+}
+
+func (u *AccessorUser) GetEmail() string {
+	return "method email"
+}
+
+func TestAccessorMethods(t *testing.T) {
+	type UserDTO struct {
+		Name  string
+		Age   int64
+		Email string
+	}
+	var dto UserDTO
+	err := Convert(AccessorUser{name: "name", age: 10, Email: "email"}, &dto, OptionAccessorMethods())
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Name: "name", Age: 10, Email: "email"}, dto)
+	err = Convert(AccessorUser{}, &dto)
+	assert.EqualError(t, err, "Age: src has no field Age(int64) convert to dest")
+
+	var user AccessorUser
+	err = Convert(&UserDTO{Name: "dto", Age: 20, Email: "dto email"}, &user, OptionAccessorMethods())
+	assert.Nil(t, err)
+	assert.Equal(t, AccessorUser{name: "dto", age: 20, Email: "dto email"}, user)
+	err = Convert(UserDTO{Age: -1}, &user, OptionAccessorMethods())
+	assert.EqualError(t, err, "Age: negative age")
+
+	// accessor of flatten struct
+	type Wrapper struct {
+		*AccessorUser
+	}
+	var w Wrapper
+	err = Convert(UserDTO{Name: "wrapper"}, &w, OptionAccessorMethods())
+	assert.Nil(t, err)
+	assert.Equal(t, "wrapper", w.GetName())
+	dto = UserDTO{}
+	err = Convert(w, &dto, OptionAccessorMethods())
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Name: "wrapper"}, dto)
+}
+
+type PanicAccessor struct{}
+
+func (p *PanicAccessor) GetScore() int {
+	panic("get boom")
+}
+
+func (p *PanicAccessor) SetScore(score int) {
+	panic("set boom")
+}
+
+func TestAccessorPanic(t *testing.T) {
+	var ce *ConvertError
+	var dto struct {
+		Score int
+	}
+	err := Convert(PanicAccessor{}, &dto, OptionAccessorMethods())
+	assert.True(t, errors.Is(err, ErrConvertPanic))
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, "Score", ce.Path)
+	assert.EqualError(t, err, "Score: panic while converting in func func() int: get boom")
+
+	err = Convert(dto, &PanicAccessor{}, OptionAccessorMethods())
+	assert.True(t, errors.Is(err, ErrConvertPanic))
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, "Score", ce.Path)
+	assert.EqualError(t, err, "Score: panic while converting in func func(int): set boom")
+}
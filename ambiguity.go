@@ -0,0 +1,97 @@
+package convertor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AmbiguityRule decides how to resolve the same field name of several embedded structs
+type AmbiguityRule int
+
+const (
+	// AmbiguityError return ambiguous field error, it's the default rule
+	AmbiguityError AmbiguityRule = iota
+	// AmbiguityGoRule follow go's selector rule, the shallowest field wins,
+	// fields of the same depth are dropped
+	AmbiguityGoRule
+	// AmbiguityFirst the field of the first declared embedded struct wins
+	AmbiguityFirst
+)
+
+// OptionAmbiguity resolve the same field name of embedded structs by rule instead of returning error,
+// a dropped field is explained in the missing field error
+func OptionAmbiguity(rule AmbiguityRule) Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().ambiguity = rule
+		return nil
+	}
+}
+
+// anonField is a sub field of embedded struct to be flatten
+type anonField struct {
+	typeField
+	dropped bool     // dropped as ambiguous in the embedded struct
+	origins []string // origins of dropped field
+}
+
+// ambiguity is the resolution of fields with the same name
+type ambiguity struct {
+	depth   int
+	dropped bool
+	winner  string
+	origins []string
+}
+
+func (amb *ambiguity) String() string {
+	if amb.dropped {
+		return fmt.Sprintf("ambiguous field is dropped: %s", strings.Join(amb.origins, ", "))
+	}
+	return fmt.Sprintf("ambiguous field is resolved to %s: %s", amb.winner, strings.Join(amb.origins, ", "))
+}
+
+// resolveAmbiguity pick a field of the same name from fields in declared order,
+// it returns nil field if the name is dropped, and nil ambiguity if there is only one field
+func (cfg *structConfig) resolveAmbiguity(fields []anonField) (*typeField, *ambiguity) {
+	if len(fields) == 1 {
+		if fields[0].dropped {
+			return nil, &ambiguity{depth: fields[0].Depth, dropped: true, origins: fields[0].origins}
+		}
+		return &fields[0].typeField, nil
+	}
+	amb := &ambiguity{}
+	for _, field := range fields {
+		if field.dropped {
+			amb.origins = append(amb.origins, field.origins...)
+		} else {
+			amb.origins = append(amb.origins, field.Origin)
+		}
+	}
+	winners := fields[:1]
+	if cfg.ambiguity == AmbiguityGoRule {
+		winners = nil
+		for _, field := range fields {
+			if len(winners) > 0 && field.Depth > winners[0].Depth {
+				continue
+			}
+			if len(winners) > 0 && field.Depth < winners[0].Depth {
+				winners = winners[:0]
+			}
+			winners = append(winners, field)
+		}
+	}
+	amb.depth = winners[0].Depth
+	if len(winners) > 1 || winners[0].dropped {
+		amb.dropped = true
+		return nil, amb
+	}
+	amb.winner = winners[0].Origin
+	return &winners[0].typeField, amb
+}
+
+// explain return the resolution of ambiguous field name for error message
+func (s *typeStruct) explain(name string) string {
+	if amb := s.ambiguous[name]; amb != nil {
+		return " (" + amb.String() + ")"
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package convertor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbiguity(t *testing.T) {
+	type Inner struct {
+		City string
+	}
+	type Billing struct {
+		City string
+		Zip  string
+	}
+	type Shipping struct {
+		City string
+		Zip  string
+	}
+	type Nested struct {
+		Inner
+	}
+	type Order struct {
+		Billing
+		Shipping
+		Nested
+		Zip string
+	}
+	type Row struct {
+		City string
+		Zip  string
+	}
+	order := Order{Billing: Billing{City: "b", Zip: "b"}, Shipping: Shipping{City: "s", Zip: "s"}, Zip: "order"}
+	err := Convert(order, &Row{})
+	assert.EqualError(t, err, "ambiguous field City")
+
+	var row Row
+	err = Convert(order, &row, OptionAmbiguity(AmbiguityFirst))
+	assert.Nil(t, err)
+	assert.Equal(t, Row{City: "b", Zip: "order"}, row)
+
+	err = Convert(order, &row, OptionAmbiguity(AmbiguityGoRule))
+	assert.EqualError(t, err, "City: src has no field City(string) convert to dest"+
+		" (ambiguous field is dropped: Billing.City, Shipping.City, Nested.Inner.City)")
+	row = Row{}
+	err = Convert(order, &row, OptionAmbiguity(AmbiguityGoRule), OptionSrcNotExistFieldIgnore())
+	assert.Nil(t, err)
+	assert.Equal(t, Row{Zip: "order"}, row)
+
+	// the shallowest field wins, a dropped field hides deeper fields
+	type Wrapper struct {
+		Order
+		Nested
+	}
+	err = Convert(Wrapper{}, &row, OptionAmbiguity(AmbiguityGoRule))
+	assert.EqualError(t, err, "City: src has no field City(string) convert to dest"+
+		" (ambiguous field is dropped: Order.Billing.City, Order.Shipping.City, Order.Nested.Inner.City, Nested.Inner.City)")
+	type Shallow struct {
+		Order
+		Inner
+	}
+	row = Row{}
+	err = Convert(Shallow{Order: order, Inner: Inner{City: "inner"}}, &row, OptionAmbiguity(AmbiguityGoRule))
+	assert.Nil(t, err)
+	assert.Equal(t, Row{City: "inner", Zip: "order"}, row)
+	err = Convert(row, &Shallow{}, OptionAmbiguity(AmbiguityGoRule))
+	assert.Nil(t, err)
+}
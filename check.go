@@ -0,0 +1,316 @@
+package convertor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MappingKind is how a src field is converted to a dest field
+type MappingKind string
+
+const (
+	MappingAssign       MappingKind = "assign"       // src is assignable to dest
+	MappingNumeric      MappingKind = "numeric"      // number is converted to another number type
+	MappingFunc         MappingKind = "func"         // registered convert func
+	MappingMethod       MappingKind = "method"       // ConvertFrom method of dest or ConvertTo method of src
+	MappingUsing        MappingKind = "using"        // named convert func of using tag option
+	MappingComputed     MappingKind = "computed"     // func of ComputeField
+	MappingDefault      MappingKind = "default"      // default tag option, src field is missing
+	MappingIncompatible MappingKind = "incompatible" // src can't be converted to dest
+)
+
+// Mapping is a resolved conversion from a src field path to a dest field path,
+// a path is field names joined by dot, elements of slice are noted by [] like Items[].Price
+type Mapping struct {
+	SrcPath  string
+	DestPath string
+	SrcType  reflect.Type
+	DestType reflect.Type
+	Kind     MappingKind
+	Lossy    bool // numeric conversion may overflow or lose precision
+}
+
+func (m Mapping) String() string {
+	s := fmt.Sprintf("-> %s(%v) %s", m.DestPath, m.DestType, m.Kind)
+	if m.SrcType != nil {
+		s = fmt.Sprintf("%s(%v) %s", m.SrcPath, m.SrcType, s)
+	}
+	if m.Lossy {
+		s += " lossy"
+	}
+	return s
+}
+
+// Report is the result of Check
+type Report struct {
+	Src          reflect.Type
+	Dest         reflect.Type
+	Mappings     []Mapping // all resolved mappings of leaf fields
+	Lossy        []Mapping // numeric mappings which may overflow or lose precision
+	Incompatible []Mapping // type pairs without assignment, number conversion or convert func
+	SrcMissing   []string  // src field paths without dest field to receive
+	DestMissing  []string  // dest field paths without src field to convert from
+}
+
+// String format the report with a line for each item
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v -> %v\n", r.Src, r.Dest)
+	for _, m := range r.Mappings {
+		fmt.Fprintf(&b, "  %s\n", m)
+	}
+	for _, path := range r.SrcMissing {
+		fmt.Fprintf(&b, "  src field %s has no dest field\n", path)
+	}
+	for _, path := range r.DestMissing {
+		fmt.Fprintf(&b, "  dest field %s has no src field\n", path)
+	}
+	return b.String()
+}
+
+/*
+Check walks field trees of srcType and destType without any value and reports how the types are converted,
+it returns ConvertErrors of the failures Convert would meet with the same options, e.g.
+
+	func init() {
+		if _, err := convertor.Check(reflect.TypeOf(User{}), reflect.TypeOf(UserDTO{})); err != nil {
+			panic(err)
+		}
+	}
+
+lossy numeric conversions are reported only, missing fields ignored by options are reported without error
+*/
+func Check(srcType, destType reflect.Type, opts ...Option) (*Report, error) {
+	cv, err := NewConvertor(opts...)
+	if err != nil {
+		return nil, err
+	}
+	ck := &checker{
+		c:        cv.(*convertor),
+		report:   &Report{Src: srcType, Dest: destType},
+		visiting: map[[2]reflect.Type]bool{},
+	}
+	errs := &errorCollector{collect: true}
+	if err := ck.check(srcType, destType, nil, nil, "", ""); err != nil {
+		errs.add(err)
+	}
+	return ck.report, errs.err()
+}
+
+type checker struct {
+	c        *convertor
+	report   *Report
+	visiting map[[2]reflect.Type]bool // type pairs on the path, recursive types are checked once
+}
+
+func (ck *checker) addMapping(m Mapping) {
+	ck.report.Mappings = append(ck.report.Mappings, m)
+	if m.Lossy {
+		ck.report.Lossy = append(ck.report.Lossy, m)
+	}
+	if m.Kind == MappingIncompatible {
+		ck.report.Incompatible = append(ck.report.Incompatible, m)
+	}
+}
+
+// check follow the steps of convert with types
+func (ck *checker) check(srcType, destType reflect.Type, srcStruct, destStruct *typeStruct, srcPath, destPath string) error {
+	ptrSrcType := srcType
+	srcType = indirectType(srcType)
+	destType = indirectType(destType)
+	mapping := Mapping{SrcPath: srcPath, DestPath: destPath, SrcType: srcType, DestType: destType}
+	_, ok := ck.c.getConvertFunc(srcType, reflect.PtrTo(destType))
+	if !ok && ptrSrcType.Kind() == reflect.Ptr {
+		_, ok = ck.c.getConvertFunc(ptrSrcType, reflect.PtrTo(destType))
+	}
+	if ok {
+		mapping.Kind = MappingFunc
+		ck.addMapping(mapping)
+		return nil
+	}
+	if getConvertMethod(srcType, reflect.PtrTo(destType)).ok {
+		mapping.Kind = MappingMethod
+		ck.addMapping(mapping)
+		return nil
+	}
+	if srcType.AssignableTo(destType) {
+		mapping.Kind = MappingAssign
+		ck.addMapping(mapping)
+		return nil
+	}
+	if ok, lossy := numericConvertible(srcType, destType); ok {
+		mapping.Kind = MappingNumeric
+		mapping.Lossy = lossy
+		ck.addMapping(mapping)
+		return nil
+	}
+	if srcStruct == nil {
+		srcStruct = ck.c.cfg.getCacheStruct(srcType, nil)
+	}
+	if destStruct == nil {
+		destStruct = ck.c.destCfg.getCacheStruct(destType, nil)
+	}
+	if srcStruct.err != nil {
+		return newConvertError(srcType, destType, srcStruct.err)
+	}
+	if destStruct.err != nil {
+		return newConvertError(srcType, destType, destStruct.err)
+	}
+	key := [2]reflect.Type{srcType, destType}
+	if ck.visiting[key] {
+		return nil
+	}
+	ck.visiting[key] = true
+	defer delete(ck.visiting, key)
+	if srcType.Kind() == reflect.Slice && destType.Kind() == reflect.Slice {
+		err := ck.check(srcType.Elem(), destType.Elem(), srcStruct.elemStruct, destStruct.elemStruct, srcPath+"[]", destPath+"[]")
+		if err != nil {
+			return wrapPath(err, "[]", srcType.Elem(), destType.Elem())
+		}
+		return nil
+	}
+	if srcType.Kind() != reflect.Struct || destType.Kind() != reflect.Struct {
+		mapping.Kind = MappingIncompatible
+		ck.addMapping(mapping)
+		return newConvertError(srcType, destType, fmt.Errorf("type %s is %w to type %s", srcType, ErrNotConvertible, reflect.PtrTo(destType)))
+	}
+	return ck.checkStruct(srcType, destType, srcStruct, destStruct, srcPath, destPath)
+}
+
+func (ck *checker) checkStruct(srcType, destType reflect.Type, srcStruct, destStruct *typeStruct, srcPath, destPath string) error {
+	errs := &errorCollector{collect: true}
+	srcFields := srcStruct.fields
+	destFields := destStruct.fields
+	pair := ck.c.getPairFields(srcType, destType, srcStruct, destStruct)
+	if pair != nil {
+		if pair.err != nil {
+			return newConvertError(srcType, destType, pair.err)
+		}
+		for _, mapped := range pair.mapped {
+			if err := ck.checkField(mapped.src, mapped.dest, srcPath, destPath); err != nil {
+				errs.add(err)
+			}
+		}
+		for _, computed := range pair.computed {
+			srcPaths := make([]string, len(computed.src))
+			for i, field := range computed.src {
+				srcPaths[i] = joinPath(srcPath, field.Name)
+			}
+			for i, field := range computed.dest {
+				ck.addMapping(Mapping{
+					SrcPath:  strings.Join(srcPaths, ","),
+					DestPath: joinPath(destPath, field.Name),
+					SrcType:  computed.fn.Type(),
+					DestType: field.Type,
+					Kind:     MappingComputed,
+				})
+				// values returned by compute func are converted as usual, only errors are kept
+				sub := &checker{c: ck.c, report: &Report{}, visiting: ck.visiting}
+				if err := sub.check(computed.fn.Type().Out(i), field.Type, nil, field.FinalStruct, "", ""); err != nil {
+					errs.add(wrapPath(err, fieldPath(field), nil, field.Type))
+				}
+			}
+		}
+		srcFields = pair.src
+		destFields = pair.dest
+	}
+	var i, j int
+	for i < len(srcFields) || j < len(destFields) {
+		var err error
+		switch {
+		case j == len(destFields) || i < len(srcFields) && srcFields[i].Name < destFields[j].Name:
+			ck.report.SrcMissing = append(ck.report.SrcMissing, joinPath(srcPath, srcFields[i].Name))
+			if !ck.c.opts.destNotExistFieldIgnore {
+				err = destFieldMissing(srcFields[i], destStruct)
+			}
+			i++
+		case i == len(srcFields) || srcFields[i].Name > destFields[j].Name:
+			field := destFields[j]
+			if field.Default.IsValid() {
+				ck.addMapping(Mapping{DestPath: joinPath(destPath, field.Name), DestType: field.Type, Kind: MappingDefault})
+			} else {
+				ck.report.DestMissing = append(ck.report.DestMissing, joinPath(destPath, field.Name))
+				if !ck.c.opts.srcNotExistFieldIgnore || field.Required {
+					err = srcFieldMissing(field, srcStruct)
+				}
+			}
+			j++
+		default:
+			err = ck.checkField(srcFields[i], destFields[j], srcPath, destPath)
+			i++
+			j++
+		}
+		if err != nil {
+			errs.add(err)
+		}
+	}
+	return errs.err()
+}
+
+func (ck *checker) checkField(srcField, destField typeField, srcPath, destPath string) error {
+	srcPath = joinPath(srcPath, srcField.Name)
+	destPath = joinPath(destPath, destField.Name)
+	if using := usingFunc(srcField, destField); using.IsValid() {
+		srcType := indirectType(srcField.Type)
+		destType := indirectType(destField.Type)
+		mapping := Mapping{SrcPath: srcPath, DestPath: destPath, SrcType: srcType, DestType: destType, Kind: MappingUsing}
+		if usingSrc, usingDest := convertFuncTypes(using.Type()); !srcType.AssignableTo(usingSrc) || reflect.PtrTo(destType) != usingDest {
+			mapping.Kind = MappingIncompatible
+			ck.addMapping(mapping)
+			err := fmt.Errorf("convert func %v can't convert %v to %v", using.Type(), srcType, reflect.PtrTo(destType))
+			return wrapPath(err, fieldPath(destField), srcType, destType)
+		}
+		ck.addMapping(mapping)
+		return nil
+	}
+	if err := ck.check(srcField.Type, destField.Type, srcField.FinalStruct, destField.FinalStruct, srcPath, destPath); err != nil {
+		return wrapPath(err, fieldPath(destField), srcField.Type, destField.Type)
+	}
+	return nil
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// numericConvertible return whether convertTo converts src to dest and whether the value may be changed
+func numericConvertible(src, dest reflect.Type) (ok, lossy bool) {
+	switch numericKind(src) {
+	case reflect.Int:
+		switch numericKind(dest) {
+		case reflect.Int:
+			return true, dest.Bits() < src.Bits()
+		case reflect.Uint:
+			return true, true
+		}
+	case reflect.Uint:
+		switch numericKind(dest) {
+		case reflect.Int:
+			return true, dest.Bits() <= src.Bits()
+		case reflect.Uint:
+			return true, dest.Bits() < src.Bits()
+		}
+	case reflect.Float64:
+		if numericKind(dest) == reflect.Float64 {
+			return true, dest.Bits() < src.Bits()
+		}
+	}
+	return false, false
+}
+
+// numericKind return Int for signed integers, Uint for unsigned integers and Float64 for floats
+func numericKind(typ reflect.Type) reflect.Kind {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
@@ -0,0 +1,96 @@
+package convertor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	type Item struct {
+		Price float64
+		Count int64
+	}
+	type Order struct {
+		ID    int
+		Items []*Item
+		Note  string
+		Tags  []string
+	}
+	type ItemDTO struct {
+		Price float32
+		Count int64
+	}
+	type OrderDTO struct {
+		ID    uint32
+		Items []ItemDTO
+		Tags  []string
+		Memo  string `convertor:",default=none"`
+	}
+	report, err := Check(reflect.TypeOf(Order{}), reflect.TypeOf(OrderDTO{}))
+	assert.EqualError(t, err, "Note: dest has no field to receive src field Note(string)")
+	assert.True(t, errors.Is(err, ErrDestFieldMissing))
+	assert.Equal(t, []string{"Note"}, report.SrcMissing)
+	assert.Nil(t, report.DestMissing)
+	assert.Nil(t, report.Incompatible)
+	assert.Equal(t, Mapping{SrcPath: "ID", DestPath: "ID", SrcType: reflect.TypeOf(0), DestType: reflect.TypeOf(uint32(0)), Kind: MappingNumeric, Lossy: true}, report.Lossy[0])
+	assert.Equal(t, 2, len(report.Lossy))
+	assert.Equal(t, "Items[].Price(float64) -> Items[].Price(float32) numeric lossy", report.Lossy[1].String())
+	assert.Equal(t, `convertor.Order -> convertor.OrderDTO
+  ID(int) -> ID(uint32) numeric lossy
+  Items[].Count(int64) -> Items[].Count(int64) assign
+  Items[].Price(float64) -> Items[].Price(float32) numeric lossy
+  -> Memo(string) default
+  Tags([]string) -> Tags([]string) assign
+  src field Note has no dest field
+`, report.String())
+
+	_, err = Check(reflect.TypeOf(Order{}), reflect.TypeOf(OrderDTO{}), OptionDestNotExistFieldIgnore())
+	assert.Nil(t, err)
+
+	// all failures are reported
+	type BadDTO struct {
+		ID    string
+		Items []struct{ Price []int }
+		Extra int
+	}
+	report, err = Check(reflect.TypeOf(Order{}), reflect.TypeOf(BadDTO{}), OptionDestNotExistFieldIgnore())
+	assert.EqualError(t, err, "Extra: src has no field Extra(int) convert to dest\n"+
+		"ID: type int is not convertiable to type *string\n"+
+		"Items[].Price: type float64 is not convertiable to type *[]int")
+	assert.Equal(t, 2, len(report.Incompatible))
+	assert.Equal(t, []string{"Count", "Note", "Tags"}, []string{report.SrcMissing[0][len("Items[]."):], report.SrcMissing[1], report.SrcMissing[2]})
+
+	// registered and named convert funcs
+	report, err = Check(reflect.TypeOf(Order{}), reflect.TypeOf(BadDTO{}), OptionDestNotExistFieldIgnore(), OptionSrcNotExistFieldIgnore(),
+		OptionConvertFunc(func(src int, dest *string) error { return nil }),
+		OptionConvertFunc(func(src Item, dest *struct{ Price []int }) error { return nil }),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, report.Incompatible)
+	assert.Equal(t, MappingFunc, report.Mappings[0].Kind)
+	assert.Equal(t, MappingFunc, report.Mappings[1].Kind)
+}
+
+func TestNumericConvertible(t *testing.T) {
+	for _, c := range []struct {
+		src, dest interface{}
+		ok, lossy bool
+	}{
+		{int8(0), int16(0), true, false},
+		{int64(0), int32(0), true, true},
+		{int8(0), uint64(0), true, true},
+		{uint8(0), int16(0), true, false},
+		{uint16(0), int16(0), true, true},
+		{uint64(0), uint32(0), true, true},
+		{float32(0), float64(0), true, false},
+		{float64(0), int(0), false, false},
+		{"", 0, false, false},
+	} {
+		ok, lossy := numericConvertible(reflect.TypeOf(c.src), reflect.TypeOf(c.dest))
+		assert.Equal(t, c.ok, ok, "%T -> %T", c.src, c.dest)
+		assert.Equal(t, c.lossy, lossy, "%T -> %T", c.src, c.dest)
+	}
+}
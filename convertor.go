@@ -4,27 +4,38 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 const (
 	convertorTag = "convertor"
 )
 
-type convertFuncsType map[[2]reflect.Type]reflect.Value
+type convertFuncsType struct {
+	exact  map[[2]reflect.Type]reflect.Value
+	ifaces []reflect.Value // funcs of interface src type in register order
+}
 
 var (
-	cacheFields  sync.Map
-	convertFuncs = convertFuncsType{} // global convert func
-	errType      = reflect.TypeOf((*error)(nil)).Elem()
+	convertFuncs    = convertFuncsType{} // global convert func
+	convertFuncsGen atomic.Uint64        // changed by every register to invalidate cached func resolutions
+	errType         = reflect.TypeOf((*error)(nil)).Elem()
+	convertorType   = reflect.TypeOf((*Convertor)(nil)).Elem()
 )
 
 // RegisterConvertFunc register convert function like func (src SrcType, dest DestType) error
 // SrcType must not be pointer, DestType must be pointer
+// the func can receive the calling Convertor like func(c Convertor, src SrcType, dest DestType) error,
+// use it to convert values with the same options, see ConvertDefault
+// SrcType can be interface like fmt.Stringer to convert any type implementing it,
+// a func of the exact src type is prior to the interface one, interface funcs are matched in register order
 // concurrent unsafe, just register in main func, and it will panic if it's a bad convert func
 func RegisterConvertFunc(f interface{}) {
-	if err := registerConvertFunc(convertFuncs, f); err != nil {
+	if err := registerConvertFunc(&convertFuncs, f); err != nil {
 		panic(err)
 	}
 }
@@ -37,27 +48,103 @@ var (
 	BadConvertFuncOut                = errors.New("bad convertor func out")
 )
 
-func registerConvertFunc(convertFuncs convertFuncsType, f interface{}) error {
+func registerConvertFunc(funcs *convertFuncsType, f interface{}) error {
+	val, err := checkConvertFunc(f)
+	if err != nil {
+		return err
+	}
+	srcType, destType := convertFuncTypes(val.Type())
+	key := [2]reflect.Type{srcType, destType}
+	if funcs.exact == nil {
+		funcs.exact = map[[2]reflect.Type]reflect.Value{}
+	}
+	if key[0].Kind() == reflect.Interface {
+		funcs.ifaces = slices.DeleteFunc(funcs.ifaces, func(f reflect.Value) bool {
+			fSrcType, fDestType := convertFuncTypes(f.Type())
+			return fSrcType == key[0] && fDestType == key[1]
+		})
+		funcs.ifaces = append(funcs.ifaces, val)
+	}
+	funcs.exact[key] = val
+	convertFuncsGen.Add(1)
+	return nil
+}
+
+// matchInterface return the first func whose interface src type is implemented by srcType
+func (funcs *convertFuncsType) matchInterface(srcType, destType reflect.Type) (reflect.Value, bool) {
+	for _, f := range funcs.ifaces {
+		if fSrcType, fDestType := convertFuncTypes(f.Type()); fDestType == destType && srcType.Implements(fSrcType) {
+			return f, true
+		}
+	}
+	return zeroValue, false
+}
+
+func checkConvertFunc(f interface{}) (reflect.Value, error) {
 	val := reflect.ValueOf(f)
 	if val.Type().Kind() != reflect.Func {
-		return BadConvertFuncNotFunc
+		return val, BadConvertFuncNotFunc
 	}
-	if val.Type().NumIn() != 2 {
-		return BadConvertFuncInCount
+	if val.Type().NumIn() != 2 && (val.Type().NumIn() != 3 || val.Type().In(0) != convertorType) {
+		return val, BadConvertFuncInCount
 	}
-	if val.Type().In(0).Kind() == reflect.Ptr {
-		return BadConvertFuncSrcTypeIsPointer
+	srcType, destType := convertFuncTypes(val.Type())
+	if srcType.Kind() == reflect.Ptr {
+		return val, BadConvertFuncSrcTypeIsPointer
 	}
-	if val.Type().In(1).Kind() != reflect.Ptr {
-		return BadConvertFuncDestTypeNotPointer
+	if destType.Kind() != reflect.Ptr {
+		return val, BadConvertFuncDestTypeNotPointer
 	}
 	if val.Type().NumOut() != 1 || !isErrorType(val.Type().Out(0)) {
-		return BadConvertFuncOut
+		return val, BadConvertFuncOut
+	}
+	return val, nil
+}
+
+// convertFuncTypes return src and dest type of convert func type, the optional Convertor param is skipped
+func convertFuncTypes(typ reflect.Type) (srcType, destType reflect.Type) {
+	offset := typ.NumIn() - 2
+	return typ.In(offset), typ.In(offset + 1)
+}
+
+func (c *convertor) callConvertFunc(convertFunc, src, dest reflect.Value) error {
+	in := []reflect.Value{src, dest}
+	if convertFunc.Type().NumIn() == 3 {
+		in = append([]reflect.Value{reflect.ValueOf(c)}, in...)
+	}
+	out, err := callFunc(convertFunc, in)
+	if err != nil {
+		return err
+	}
+	if err, ok := out[0].Interface().(error); ok {
+		return err
 	}
-	convertFuncs[[2]reflect.Type{val.Type().In(0), val.Type().In(1)}] = val
 	return nil
 }
 
+// callFunc call user func f and turn its panic to error
+func callFunc(f reflect.Value, in []reflect.Value) (out []reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w in func %v: %v", ErrConvertPanic, f.Type(), r)
+		}
+	}()
+	return f.Call(in), nil
+}
+
+var namedConvertFuncs = map[string]reflect.Value{} // global named convert func
+
+// RegisterNamedConvertFunc register convert function like RegisterConvertFunc,
+// but it only works for fields with tag option using=name like `convertor:"CreatedAt,using=unixSeconds"`
+// concurrent unsafe, just register in main func, and it will panic if it's a bad convert func
+func RegisterNamedConvertFunc(name string, f interface{}) {
+	val, err := checkConvertFunc(f)
+	if err != nil {
+		panic(err)
+	}
+	namedConvertFuncs[name] = val
+}
+
 func isErrorType(typ reflect.Type) bool {
 	return typ.Implements(errType)
 }
@@ -70,25 +157,145 @@ type typeStruct struct {
 	err        error
 	fields     []typeField
 	elemStruct *typeStruct // slice element struct
+	hasPath    bool        // has field named by path like Address.City
+	hasAlias   bool        // has field with alias names like Login|Username
+	ambiguous  map[string]*ambiguity
 }
 
 type typeField struct {
 	Type        reflect.Type
 	Name        string
+	Aliases     []string // other names of field after Name
 	Idx         int
-	NextIdx     int
+	NextIdx     int // index of NextStruct.fields
 	NextStruct  *typeStruct
 	FinalStruct *typeStruct // current field endpoint struct
+	Depth       int         // embedded depth of flatten field
+	Origin      string      // go field path of flatten field like TypeB.FieldBB
+	Accessor    bool        // getter or setter method, Idx is the method index of pointer type
+	fieldOptions
+}
+
+// lookup find field by name in sorted fields
+func (s *typeStruct) lookup(name string) (typeField, bool) {
+	i := sort.Search(len(s.fields), func(i int) bool {
+		return s.fields[i].Name >= name
+	})
+	if i < len(s.fields) && s.fields[i].Name == name {
+		return s.fields[i], true
+	}
+	return typeField{}, false
 }
 
 var (
 	ErrDestinationNotPointer = errors.New("destination value is not pointer")
 	ErrNilDestination        = errors.New("nil destination")
+	ErrNilSource             = errors.New("nil source")
+	ErrConvertPanic          = errors.New("panic while converting")
+	ErrSrcFieldMissing       = errors.New("src has no field")
+	ErrDestFieldMissing      = errors.New("dest has no field")
+	ErrNotConvertible        = errors.New("not convertiable")
+	ErrCircleStruct          = errors.New("circle struct rely")
+	ErrConflictFieldName     = errors.New("conflict field name")
+	ErrAmbiguousField        = errors.New("ambiguous field")
 	notStructType            = &typeStruct{}
 )
 
-func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalTypeStruct *typeStruct) {
-	if val, ok := cacheFields.Load(typ); ok {
+// structConfig decides how a type is parsed to field tree,
+// every config has its own cache because the same type may have different field trees,
+// configs are shared by convertors with the same structConfigKey, see getStructConfig
+type structConfig struct {
+	cache sync.Map // reflect.Type -> *typeStruct
+	dest  bool     // parse type as dest, tag options from= and to= depend on it
+	structOptions
+}
+
+// structOptions is the options of parsing type to field tree
+type structOptions struct {
+	caseInsensitive  bool
+	ignoreUnderscore bool
+	naming           NamingStrategy
+	tagKeys          []string // default convertorTag
+	trimPrefixes     []string
+	trimSuffixes     []string
+	profile          string
+	namedFuncs       map[string]reflect.Value
+	ambiguity        AmbiguityRule
+	accessors        bool
+}
+
+// structConfigKey is the comparable identity of structOptions and parsing direction,
+// lists are joined by NUL and naming is identified by code pointer, so options with closures are not shared
+type structConfigKey struct {
+	dest             bool
+	caseInsensitive  bool
+	ignoreUnderscore bool
+	accessors        bool
+	naming           uintptr
+	tagKeys          string
+	trimPrefixes     string
+	trimSuffixes     string
+	profile          string
+	ambiguity        AmbiguityRule
+}
+
+func (opts *structOptions) key(dest bool) structConfigKey {
+	key := structConfigKey{
+		dest:             dest,
+		caseInsensitive:  opts.caseInsensitive,
+		ignoreUnderscore: opts.ignoreUnderscore,
+		accessors:        opts.accessors,
+		tagKeys:          strings.Join(opts.tagKeys, "\x00"),
+		trimPrefixes:     strings.Join(opts.trimPrefixes, "\x00"),
+		trimSuffixes:     strings.Join(opts.trimSuffixes, "\x00"),
+		profile:          opts.profile,
+		ambiguity:        opts.ambiguity,
+	}
+	if opts.naming != nil {
+		key.naming = reflect.ValueOf(opts.naming).Pointer()
+	}
+	return key
+}
+
+var structConfigs sync.Map // structConfigKey -> *structConfig
+
+// getStructConfig return the config shared by all convertors with the same options,
+// so field trees are parsed once for them, a custom naming strategy or named funcs may be closures and get their own config,
+// named funcs are also kept in the field tree by using tag option
+func getStructConfig(opts structOptions, dest bool) *structConfig {
+	if opts.naming != nil && !isBuiltinNaming(opts.naming) || len(opts.namedFuncs) > 0 {
+		return &structConfig{dest: dest, structOptions: opts}
+	}
+	key := opts.key(dest)
+	if val, ok := structConfigs.Load(key); ok {
+		return val.(*structConfig)
+	}
+	val, _ := structConfigs.LoadOrStore(key, &structConfig{dest: dest, structOptions: opts})
+	return val.(*structConfig)
+}
+
+var (
+	defaultStructConfig     = getStructConfig(structOptions{}, false)
+	defaultDestStructConfig = getStructConfig(structOptions{}, true)
+)
+
+// fieldName return the name used to match fields
+func (cfg *structConfig) fieldName(name string) string {
+	if cfg.ignoreUnderscore {
+		name = strings.ReplaceAll(name, "_", "")
+	}
+	if cfg.caseInsensitive {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) *typeStruct {
+	return defaultStructConfig.getCacheStruct(typ, typePath)
+}
+
+func (cfg *structConfig) getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalTypeStruct *typeStruct) {
+	if val, ok := cfg.cache.Load(typ); ok {
 		return val.(*typeStruct)
 	}
 	if typePath == nil {
@@ -96,7 +303,7 @@ func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalType
 	}
 	if typePath[typ] { // prevent cirle struct rely
 		return &typeStruct{
-			err: fmt.Errorf("circle struct rely: %s", typ),
+			err: fmt.Errorf("%w: %s", ErrCircleStruct, typ),
 		}
 	}
 	typePath[typ] = true
@@ -105,7 +312,7 @@ func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalType
 		sort.Slice(finalTypeStruct.fields, func(i, j int) bool {
 			return finalTypeStruct.fields[i].Name < finalTypeStruct.fields[j].Name
 		})
-		cacheFields.Store(originType, finalTypeStruct)
+		cfg.cache.Store(originType, finalTypeStruct)
 		typePath[typ] = false
 
 		if finalTypeStruct.err != nil {
@@ -114,12 +321,12 @@ func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalType
 		if typ.Kind() == reflect.Slice {
 			finalTypeStruct = &typeStruct{
 				fields:     finalTypeStruct.fields,
-				elemStruct: getCacheStruct(typ.Elem(), nil),
+				elemStruct: cfg.getCacheStruct(typ.Elem(), nil),
 			}
 		}
 		for i, field := range finalTypeStruct.fields {
 			if field.FinalStruct == nil {
-				finalTypeStruct.fields[i].FinalStruct = getCacheStruct(field.Type, nil)
+				finalTypeStruct.fields[i].FinalStruct = cfg.getCacheStruct(field.Type, nil)
 			}
 		}
 	}()
@@ -130,9 +337,10 @@ func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalType
 		return notStructType
 	}
 	finalFields := make([]typeField, 0, typ.NumField())
-	nameMap := map[string]bool{}
+	nameMap := map[string]string{} // match name -> origin name
 	var anonymousStructField []reflect.StructField
 	var anonymousStructFieldIndex []int
+	var anonymousStructFieldPrefix []string
 	tmpVal := reflect.New(typ).Elem()
 	for i := 0; i < typ.NumField(); i++ {
 		if !tmpVal.Field(i).CanSet() { // unexport
@@ -141,58 +349,163 @@ func getCacheStruct(typ reflect.Type, typePath map[reflect.Type]bool) (finalType
 		field := typ.Field(i)
 		tf := typeField{
 			Type:    field.Type,
-			Name:    field.Name,
+			Name:    cfg.goFieldName(field.Name),
 			Idx:     i,
 			NextIdx: -1,
+			Origin:  field.Name,
 		}
 		// use convertor tag to cover field name
-		tag, ok := field.Tag.Lookup(convertorTag)
+		tag, ok := cfg.lookupTag(field)
 		if ok {
-			if tag == "-" { // ignore field
+			if tag.name == "-" { // ignore field
 				continue
 			}
-			tf.Name = tag
+			if tag.name != "" {
+				tf.Name = tag.name
+			}
+			if err := cfg.parseOptions(&tf, tag.options); err != nil {
+				return &typeStruct{err: err}
+			}
 		}
-		if (field.Anonymous && !ok) || (ok && tag == "+") { // anonymous field has not tag, flatten later
+		originNames := strings.Split(tf.Name, "|") // name and aliases like Login|Username
+		tf.Name = cfg.fieldName(originNames[0])
+		for _, alias := range originNames[1:] {
+			tf.Aliases = append(tf.Aliases, cfg.fieldName(alias))
+		}
+		if (field.Anonymous && !ok) || (ok && strings.HasPrefix(tag.name, "+")) { // anonymous field has not tag, flatten later
+			prefix, err := parseFlattenPrefix(tag.name)
+			if err != nil {
+				return &typeStruct{err: err}
+			}
 			anonymousStructField = append(anonymousStructField, field)
 			anonymousStructFieldIndex = append(anonymousStructFieldIndex, i)
+			anonymousStructFieldPrefix = append(anonymousStructFieldPrefix, prefix)
 			continue
 		}
 		finalFields = append(finalFields, tf)
-		if nameMap[tf.Name] {
-			return &typeStruct{
-				err: fmt.Errorf("conflict field name and tag: %s", tf.Name),
+		for _, originName := range originNames {
+			matchName := cfg.fieldName(originName)
+			if name, ok := nameMap[matchName]; ok {
+				if name != originName {
+					return &typeStruct{
+						err: fmt.Errorf("%w after normalization: %s and %s", ErrConflictFieldName, name, originName),
+					}
+				}
+				return &typeStruct{
+					err: fmt.Errorf("%w and tag: %s", ErrConflictFieldName, matchName),
+				}
+			}
+			nameMap[matchName] = originName
+		}
+	}
+	if cfg.accessors {
+		promoted := promotedMethods(typ)
+		ptrType := reflect.PtrTo(typ)
+		for i := 0; i < ptrType.NumMethod(); i++ {
+			tf, ok := cfg.accessorField(ptrType.Method(i))
+			if !ok || promoted[tf.Origin] { // promoted method is a field of flatten struct
+				continue
+			}
+			if _, ok := nameMap[tf.Name]; ok { // struct field is prior
+				continue
 			}
+			nameMap[tf.Name] = tf.Origin
+			finalFields = append(finalFields, tf)
 		}
-		nameMap[tf.Name] = true
 	}
 	var allAnonFields []typeField
+	anonFields := map[string][]anonField{}
+	var anonNames []string
+	addAnonField := func(af anonField) {
+		if len(anonFields[af.Name]) == 0 {
+			anonNames = append(anonNames, af.Name)
+		}
+		anonFields[af.Name] = append(anonFields[af.Name], af)
+	}
 	for i, field := range anonymousStructField {
-		ftStruct := getCacheStruct(field.Type, typePath)
+		ftStruct := cfg.getCacheStruct(field.Type, typePath)
 		if ftStruct.err != nil {
 			return ftStruct
 		}
-		if fieldName := inFields(ftStruct.fields, allAnonFields); len(fieldName) > 0 { // two anonymous field has same sub field
-			return &typeStruct{
-				err: fmt.Errorf("ambiguous field %s", fieldName),
+		prefix := anonymousStructFieldPrefix[i]
+		subFields := ftStruct.fields
+		if prefix != "" {
+			subFields = make([]typeField, len(ftStruct.fields))
+			for k, subField := range ftStruct.fields {
+				subField.Name = cfg.prefixedName(prefix, subField.Name)
+				subField.Aliases = make([]string, len(subField.Aliases))
+				for a, alias := range ftStruct.fields[k].Aliases {
+					subField.Aliases[a] = cfg.prefixedName(prefix, alias)
+				}
+				subFields[k] = subField
+			}
+		}
+		if cfg.ambiguity == AmbiguityError {
+			if fieldName := inFields(subFields, allAnonFields); len(fieldName) > 0 { // two anonymous field has same sub field
+				return &typeStruct{
+					err: fmt.Errorf("%w %s", ErrAmbiguousField, fieldName),
+				}
+			}
+			allAnonFields = append(allAnonFields, subFields...)
+		}
+		for k, subField := range subFields {
+			addAnonField(anonField{typeField: typeField{
+				Type:         subField.Type,
+				Name:         subField.Name,
+				Aliases:      subField.Aliases,
+				Idx:          anonymousStructFieldIndex[i],
+				NextStruct:   ftStruct,
+				NextIdx:      k, // index of sorted fields
+				Depth:        subField.Depth + 1,
+				Origin:       field.Name + "." + subField.Origin,
+				fieldOptions: subField.fieldOptions,
+			}})
+		}
+		for name, amb := range ftStruct.ambiguous { // dropped field still hides deeper fields
+			if !amb.dropped {
+				continue
+			}
+			if prefix != "" {
+				name = cfg.prefixedName(prefix, name)
 			}
+			origins := make([]string, len(amb.origins))
+			for k, origin := range amb.origins {
+				origins[k] = field.Name + "." + origin
+			}
+			addAnonField(anonField{
+				typeField: typeField{Name: name, Depth: amb.depth + 1},
+				dropped:   true,
+				origins:   origins,
+			})
 		}
-		allAnonFields = append(allAnonFields, ftStruct.fields...)
-		for _, subField := range ftStruct.fields {
-			if !nameMap[subField.Name] {
-				nameMap[subField.Name] = true
-				finalFields = append(finalFields, typeField{
-					Type:       subField.Type,
-					Name:       subField.Name,
-					Idx:        anonymousStructFieldIndex[i],
-					NextStruct: ftStruct,
-					NextIdx:    subField.Idx,
-				})
+	}
+	var ambiguous map[string]*ambiguity
+	for _, name := range anonNames {
+		if _, ok := nameMap[name]; ok { // direct field is prior
+			continue
+		}
+		nameMap[name] = name
+		field, amb := cfg.resolveAmbiguity(anonFields[name])
+		if amb != nil {
+			if ambiguous == nil {
+				ambiguous = map[string]*ambiguity{}
 			}
+			ambiguous[name] = amb
+		}
+		if field != nil {
+			finalFields = append(finalFields, *field)
 		}
 	}
+	hasPath, hasAlias := false, false
+	for _, field := range finalFields {
+		hasPath = hasPath || strings.Contains(field.Name, ".")
+		hasAlias = hasAlias || len(field.Aliases) > 0
+	}
 	return &typeStruct{
-		fields: finalFields,
+		fields:    finalFields,
+		hasPath:   hasPath,
+		hasAlias:  hasAlias,
+		ambiguous: ambiguous,
 	}
 }
 
@@ -209,14 +522,28 @@ func inFields(sub, full []typeField) string {
 
 type Options struct {
 	convertFuncs            convertFuncsType
+	fieldRules              map[[2]reflect.Type]*pairRules
 	srcNotExistFieldIgnore  bool
 	destNotExistFieldIgnore bool
+	collectErrors           bool
+	structOptions           *structOptions // nil means default struct config
+}
+
+func (opts *Options) getStructOptions() *structOptions {
+	if opts.structOptions == nil {
+		opts.structOptions = &structOptions{}
+	}
+	return opts.structOptions
 }
 
 type Option func(*Options) error
 
 type convertor struct {
-	opts Options
+	opts      Options
+	cfg       *structConfig // for src struct
+	destCfg   *structConfig // for dest struct
+	pairCache sync.Map      // [2]*typeStruct -> *pairFields
+	funcCache sync.Map      // [2]reflect.Type -> funcResolution
 }
 
 type Convertor interface {
@@ -226,10 +553,7 @@ type Convertor interface {
 // concurrent unsafe
 func OptionConvertFunc(f interface{}) Option {
 	return func(opts *Options) error {
-		if opts.convertFuncs == nil {
-			opts.convertFuncs = convertFuncsType{}
-		}
-		return registerConvertFunc(opts.convertFuncs, f)
+		return registerConvertFunc(&opts.convertFuncs, f)
 	}
 }
 
@@ -247,6 +571,61 @@ func OptionDestNotExistFieldIgnore() Option {
 	}
 }
 
+// OptionCollectErrors keep converting after a field or slice element fails,
+// Convert returns ConvertErrors of all failures sorted by path
+func OptionCollectErrors() Option {
+	return func(opts *Options) error {
+		opts.collectErrors = true
+		return nil
+	}
+}
+
+// OptionNamedConvertFunc register named convert func for this convertor, see RegisterNamedConvertFunc,
+// it is prior to the global one with the same name
+func OptionNamedConvertFunc(name string, f interface{}) Option {
+	return func(opts *Options) error {
+		val, err := checkConvertFunc(f)
+		if err != nil {
+			return err
+		}
+		structOpts := opts.getStructOptions()
+		if structOpts.namedFuncs == nil {
+			structOpts.namedFuncs = map[string]reflect.Value{}
+		}
+		structOpts.namedFuncs[name] = val
+		return nil
+	}
+}
+
+// OptionCaseInsensitiveFieldName match field names case-insensitively, UserID matches UserId and userid,
+// it returns error if two fields of one type have the same name ignoring case
+func OptionCaseInsensitiveFieldName() Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().caseInsensitive = true
+		return nil
+	}
+}
+
+// OptionProfile parse struct by profile, a tag with option profile like `convertor:"name,profile=public|admin"`
+// works only for the listed profiles, it's ignored for other profiles just like there is no such tag
+func OptionProfile(profile string) Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().profile = profile
+		return nil
+	}
+}
+
+// OptionNormalizedFieldName match field names case-insensitively and ignoring underscores,
+// user_id matches UserID, it returns error if two fields of one type have the same normalized name
+func OptionNormalizedFieldName() Option {
+	return func(opts *Options) error {
+		cfg := opts.getStructOptions()
+		cfg.caseInsensitive = true
+		cfg.ignoreUnderscore = true
+		return nil
+	}
+}
+
 func NewConvertor(opts ...Option) (Convertor, error) {
 	c := &convertor{}
 	for _, o := range opts {
@@ -254,12 +633,20 @@ func NewConvertor(opts ...Option) (Convertor, error) {
 			return nil, err
 		}
 	}
+	if c.opts.structOptions == nil {
+		c.cfg = defaultStructConfig
+		c.destCfg = defaultDestStructConfig
+	} else {
+		c.cfg = getStructConfig(*c.opts.structOptions, false)
+		c.destCfg = getStructConfig(*c.opts.structOptions, true)
+	}
 	return c, nil
 }
 
 /*
 Convert convert struct src to dest
 Example:
+
 	type D struct {
 		DD int64
 	}
@@ -292,38 +679,131 @@ var SrcNotExistFieldIgnoreConvertor, _ = NewConvertor(OptionSrcNotExistFieldIgno
 var DestNotExistFieldIgnoreConvertor, _ = NewConvertor(OptionDestNotExistFieldIgnore())
 
 func (c *convertor) Convert(src, dest interface{}) (err error) {
+	destVal, err := destValue(dest)
+	if err != nil {
+		return err
+	}
+	return c.convertValue(reflect.ValueOf(src), destVal, nil, nil, c.convert)
+}
+
+/*
+ConvertDefault convert src to dest like c.Convert, but the convert func and ConvertFrom/ConvertTo method of src type and dest type are skipped,
+so a convert func receiving Convertor or a conversion method can convert the rest fields by default, e.g.
+
+	RegisterConvertFunc(func(c Convertor, src Order, dest *OrderDTO) error {
+		if err := ConvertDefault(c, src, dest); err != nil {
+			return err
+		}
+		dest.Total = src.Price * float64(src.Count)
+		return nil
+	})
+
+the field conversions inside still use convert funcs, DefaultConvertor is used if c is not created by NewConvertor
+*/
+func ConvertDefault(c Convertor, src, dest interface{}) error {
+	cv, ok := c.(*convertor)
+	if !ok {
+		cv = DefaultConvertor.(*convertor)
+	}
+	destVal, err := destValue(dest)
+	if err != nil {
+		return err
+	}
+	return cv.convertValue(reflect.ValueOf(src), destVal, nil, nil, cv.convertDefault)
+}
+
+func destValue(dest interface{}) (reflect.Value, error) {
 	destVal := reflect.ValueOf(dest)
 	if destVal.Kind() != reflect.Ptr {
-		return ErrDestinationNotPointer
+		return destVal, ErrDestinationNotPointer
 	}
 	if destVal.IsNil() {
-		return ErrNilDestination
+		return destVal, ErrNilDestination
 	}
-	srcVal := reflect.ValueOf(src)
-	return c.convert(srcVal, destVal, nil, nil)
+	return destVal, nil
+}
+
+type convertValueFunc func(src, dest reflect.Value, srcStruct, destStruct *typeStruct) error
+
+// convertValue convert src value of Convert or ConvertSeq by convert, it never panics
+func (c *convertor) convertValue(src, dest reflect.Value, srcStruct, destStruct *typeStruct, convert convertValueFunc) (err error) {
+	if isNilValue(src) {
+		return ErrNilSource
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = newConvertError(src.Type(), dest.Type(), fmt.Errorf("%w: %v", ErrConvertPanic, r))
+		}
+	}()
+	return convert(src, dest, srcStruct, destStruct)
+}
+
+type funcResolution struct {
+	convertFunc reflect.Value
+	ok          bool
+	gen         uint64 // convertFuncsGen when it's resolved
 }
 
-func (c *convertor) getConvertFunc(src, dest reflect.Value) (convertFunc reflect.Value, ok bool) {
-	convertFuncKey := [2]reflect.Type{src.Type(), dest.Type()}
-	if len(c.opts.convertFuncs) > 0 {
-		convertFunc, ok = c.opts.convertFuncs[convertFuncKey]
+// getConvertFunc find convert func by order: exact func of convertor, exact global func,
+// interface func of convertor, interface global func, resolutions of interface funcs are cached
+func (c *convertor) getConvertFunc(srcType, destType reflect.Type) (convertFunc reflect.Value, ok bool) {
+	// hashing interface keys is slow, skip lookups of empty maps
+	convertFuncKey := [2]reflect.Type{srcType, destType}
+	if len(c.opts.convertFuncs.exact) > 0 {
+		if convertFunc, ok = c.opts.convertFuncs.exact[convertFuncKey]; ok {
+			return
+		}
+	}
+	if len(convertFuncs.exact) > 0 {
+		if convertFunc, ok = convertFuncs.exact[convertFuncKey]; ok {
+			return
+		}
+	}
+	if len(c.opts.convertFuncs.ifaces) == 0 && len(convertFuncs.ifaces) == 0 {
+		return
 	}
-	if !ok && len(convertFuncs) > 0 {
-		convertFunc, ok = convertFuncs[convertFuncKey]
+	gen := convertFuncsGen.Load()
+	if val, loaded := c.funcCache.Load(convertFuncKey); loaded && val.(funcResolution).gen == gen {
+		resolution := val.(funcResolution)
+		return resolution.convertFunc, resolution.ok
 	}
+	if convertFunc, ok = c.opts.convertFuncs.matchInterface(srcType, destType); !ok {
+		convertFunc, ok = convertFuncs.matchInterface(srcType, destType)
+	}
+	c.funcCache.Store(convertFuncKey, funcResolution{convertFunc: convertFunc, ok: ok, gen: gen})
 	return
 }
 
 func (c *convertor) convert(src, dest reflect.Value, srcStruct, destStruct *typeStruct) error {
+	if isNilValue(src) {
+		return newConvertError(nil, dest.Type(), ErrNilSource)
+	}
 	indirectSrc := reflect.Indirect(src)
-	convertFunc, ok := c.getConvertFunc(indirectSrc, dest)
+	funcSrc := indirectSrc
+	convertFunc, ok := c.getConvertFunc(indirectSrc.Type(), dest.Type())
+	if !ok && src.Kind() == reflect.Ptr {
+		// interface src type may be implemented by pointer receiver methods only
+		funcSrc = src
+		convertFunc, ok = c.getConvertFunc(src.Type(), dest.Type())
+	}
 	if ok {
-		out := convertFunc.Call([]reflect.Value{indirectSrc, dest})
-		if err, ok := out[0].Interface().(error); ok {
-			return err
+		if err := c.callConvertFunc(convertFunc, funcSrc, dest); err != nil {
+			return newConvertError(indirectSrc.Type(), dest.Type(), err)
+		}
+		return nil
+	}
+	if method := getConvertMethod(indirectSrc.Type(), dest.Type()); method.ok {
+		if err := method.call(indirectSrc, dest); err != nil {
+			return newConvertError(indirectSrc.Type(), dest.Type(), err)
 		}
 		return nil
 	}
+	return c.convertDefault(src, dest, srcStruct, destStruct)
+}
+
+// convertDefault convert src to dest without the convert func or method of their types, values inside still use them
+func (c *convertor) convertDefault(src, dest reflect.Value, srcStruct, destStruct *typeStruct) error {
+	indirectSrc := reflect.Indirect(src)
 	indirectDest := reflect.Indirect(dest)
 	if indirectSrc.Type().AssignableTo(indirectDest.Type()) {
 		indirectDest.Set(indirectSrc)
@@ -333,16 +813,16 @@ func (c *convertor) convert(src, dest reflect.Value, srcStruct, destStruct *type
 		return nil
 	}
 	if srcStruct == nil {
-		srcStruct = getCacheStruct(src.Type(), nil)
+		srcStruct = c.cfg.getCacheStruct(src.Type(), nil)
 	}
 	if destStruct == nil {
-		destStruct = getCacheStruct(dest.Type(), nil)
+		destStruct = c.destCfg.getCacheStruct(dest.Type(), nil)
 	}
 	if srcStruct.err != nil {
-		return srcStruct.err
+		return newConvertError(src.Type(), dest.Type(), srcStruct.err)
 	}
 	if destStruct.err != nil {
-		return destStruct.err
+		return newConvertError(src.Type(), dest.Type(), destStruct.err)
 	}
 	if indirectSrc.Kind() == reflect.Slice && indirectDest.Kind() == reflect.Slice {
 		if src.IsNil() {
@@ -353,9 +833,10 @@ func (c *convertor) convert(src, dest reflect.Value, srcStruct, destStruct *type
 		dest.Set(reflect.MakeSlice(dest.Type(), src.Len(), src.Cap()))
 		srcElemStruct := srcStruct.elemStruct
 		destElemStruct := destStruct.elemStruct
+		errs := c.newErrorCollector()
 		for i := 0; i < src.Len(); i++ {
 			srcElem := src.Index(i)
-			if srcElem.Kind() == reflect.Ptr && srcElem.IsNil() {
+			if isNilValue(srcElem) {
 				continue
 			}
 			destElem := dest.Index(i)
@@ -366,54 +847,118 @@ func (c *convertor) convert(src, dest reflect.Value, srcStruct, destStruct *type
 				destElem = destElem.Addr()
 			}
 			if err := c.convert(srcElem, destElem, srcElemStruct, destElemStruct); err != nil {
-				return err
+				if err = errs.add(wrapPath(err, fmt.Sprintf("[%d]", i), srcElem.Type(), destElem.Type())); err != nil {
+					return err
+				}
 			}
 		}
-		return nil
+		return errs.err()
 	}
 	if indirectSrc.Kind() != reflect.Struct || indirectDest.Kind() != reflect.Struct {
-		return fmt.Errorf("type %s is not convertiable to type %s", src.Type(), dest.Type())
+		return newConvertError(src.Type(), dest.Type(), fmt.Errorf("type %s is %w to type %s", src.Type(), ErrNotConvertible, dest.Type()))
 	}
 	srcFields := srcStruct.fields
 	destFields := destStruct.fields
-	var i, j int
-	for i < len(srcFields) && j < len(destFields) {
-		if srcFields[i].Name != destFields[j].Name {
-			var err error
-			if srcFields[i].Name < destFields[j].Name {
-				if c.opts.destNotExistFieldIgnore {
-					i++
-					continue
+	errs := c.newErrorCollector()
+	pair := c.getPairFields(indirectSrc.Type(), indirectDest.Type(), srcStruct, destStruct)
+	if pair != nil {
+		if pair.err != nil {
+			return newConvertError(src.Type(), dest.Type(), pair.err)
+		}
+		for _, mapped := range pair.mapped {
+			ok, err := pair.allow(src, mapped.src)
+			if ok {
+				err = c.convertField(src, dest, mapped.src, mapped.dest)
+			}
+			if err != nil {
+				if err = errs.add(wrapPath(err, fieldPath(mapped.dest), mapped.src.Type, mapped.dest.Type)); err != nil {
+					return err
 				}
-				err = fmt.Errorf("dest has no field to receive src field %s(%v)", srcFields[i].Name, srcFields[i].Type)
-			} else {
-				if c.opts.srcNotExistFieldIgnore {
-					j++
-					continue
+			}
+		}
+		for _, computed := range pair.computed {
+			if err := c.convertComputed(src, dest, computed); err != nil {
+				if err = errs.add(wrapPath(err, fieldPath(computed.dest[0]), nil, computed.dest[0].Type)); err != nil {
+					return err
 				}
-				err = fmt.Errorf("src has no field %s(%v) convert to dest", destFields[j].Name, destFields[j].Type)
 			}
-			return err
 		}
-		val, srcFinalStruct := getValueByPath(src, srcFields[i])
-		if val == zeroValue || (val.Kind() == reflect.Ptr && val.IsNil()) {
+		srcFields = pair.src
+		destFields = pair.dest
+	}
+	var i, j int
+	for i < len(srcFields) || j < len(destFields) {
+		var err error
+		switch {
+		case j == len(destFields) || i < len(srcFields) && srcFields[i].Name < destFields[j].Name:
+			if !c.opts.destNotExistFieldIgnore {
+				err = destFieldMissing(srcFields[i], destStruct)
+			}
+			i++
+		case i == len(srcFields) || srcFields[i].Name > destFields[j].Name:
+			var ok bool
+			if ok, err = c.fillMissingField(dest, destFields[j]); err != nil {
+				err = wrapPath(err, fieldPath(destFields[j]), nil, destFields[j].Type)
+			} else if !ok {
+				err = srcFieldMissing(destFields[j], srcStruct)
+			}
+			j++
+		default:
+			var ok bool
+			if ok, err = pair.allow(src, srcFields[i]); ok {
+				err = c.convertField(src, dest, srcFields[i], destFields[j])
+			}
+			if err != nil {
+				err = wrapPath(err, fieldPath(destFields[j]), srcFields[i].Type, destFields[j].Type)
+			}
 			i++
 			j++
-			continue
 		}
-		if err := c.setValueByPath(dest, val, destFields[j], srcFinalStruct); err != nil {
-			return err
+		if err != nil {
+			if err = errs.add(err); err != nil {
+				return err
+			}
 		}
-		i++
-		j++
 	}
-	if i < len(srcFields) && !c.opts.destNotExistFieldIgnore {
-		return fmt.Errorf("dest has no field to receive src field %s(%v)", srcFields[i].Name, srcFields[i].Type)
+	return errs.err()
+}
+
+// convertField convert a field of src struct to a field of dest struct, nil src field is skipped
+func (c *convertor) convertField(src, dest reflect.Value, srcField, destField typeField) error {
+	val, srcFinalStruct, err := getValueByPath(src, srcField)
+	if err != nil {
+		return err
 	}
-	if j < len(destFields) && !c.opts.srcNotExistFieldIgnore {
-		return fmt.Errorf("src has no field %s(%v) convert to dest", destFields[j].Name, destFields[j].Type)
+	if isNilValue(val) {
+		if destField.Default.IsValid() {
+			return c.setValueByPath(dest, destField.Default, destField, notStructType)
+		}
+		return checkRequired(srcField, destField)
 	}
-	return nil
+	if (srcField.OmitEmpty || destField.OmitEmpty || srcField.Required || destField.Required) && reflect.Indirect(val).IsZero() {
+		return checkRequired(srcField, destField)
+	}
+	if using := usingFunc(srcField, destField); using.IsValid() {
+		val = reflect.Indirect(val)
+		destVal, _, set := getDestByPath(dest, destField)
+		if usingSrc, usingDest := convertFuncTypes(using.Type()); !val.Type().AssignableTo(usingSrc) || destVal.Type() != usingDest {
+			return fmt.Errorf("convert func %v can't convert %v to %v", using.Type(), val.Type(), destVal.Type())
+		}
+		if err := c.callConvertFunc(using, val, destVal); err != nil {
+			return err
+		}
+		return set()
+	}
+	return c.setValueByPath(dest, val, destField, srcFinalStruct)
+}
+
+// fillMissingField fill dest field which has no src field by default value,
+// it returns false if the missing src field should be reported
+func (c *convertor) fillMissingField(dest reflect.Value, field typeField) (bool, error) {
+	if field.Default.IsValid() {
+		return true, c.setValueByPath(dest, field.Default, field, notStructType)
+	}
+	return c.opts.srcNotExistFieldIgnore && !field.Required, nil
 }
 
 func (c *convertor) convertTo(src, dest reflect.Value) bool {
@@ -450,24 +995,54 @@ func (c *convertor) convertTo(src, dest reflect.Value) bool {
 
 var zeroValue = reflect.Value{}
 
-func getValueByPath(val reflect.Value, field typeField) (reflect.Value, *typeStruct) {
+// isNilValue return whether val is invalid or a nil pointer or interface
+func isNilValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Interface:
+		return val.IsNil()
+	}
+	return false
+}
+
+// getValueByPath return the src field value and its final struct, error is the panic of getter method
+func getValueByPath(val reflect.Value, field typeField) (reflect.Value, *typeStruct, error) {
 	for {
 		if val.Kind() == reflect.Ptr {
 			if val.IsNil() {
-				return zeroValue, notStructType
+				return zeroValue, notStructType, nil
 			}
 			val = val.Elem()
 		}
+		if field.Accessor {
+			var err error
+			if val, err = callGetter(val, field.Idx); err != nil {
+				return zeroValue, nil, err
+			}
+			break
+		}
 		val = val.Field(field.Idx)
 		if field.NextStruct == nil {
 			break
 		}
 		field = field.NextStruct.fields[field.NextIdx]
 	}
-	return val, field.FinalStruct
+	return val, field.FinalStruct, nil
 }
 
 func (c *convertor) setValueByPath(dest, val reflect.Value, field typeField, srcFinalStruct *typeStruct) error {
+	dest, field, set := getDestByPath(dest, field)
+	if err := c.convert(val, dest, srcFinalStruct, field.FinalStruct); err != nil {
+		return err
+	}
+	return set()
+}
+
+// getDestByPath return pointer of the dest field and the last field of path, nil pointers on the path are allocated,
+// set must be called after dest is filled, it calls setter method if the dest field is a setter
+func getDestByPath(dest reflect.Value, field typeField) (reflect.Value, typeField, func() error) {
+	set := noopSet
 	for {
 		if dest.Kind() == reflect.Ptr {
 			if dest.IsNil() {
@@ -475,6 +1050,10 @@ func (c *convertor) setValueByPath(dest, val reflect.Value, field typeField, src
 			}
 			dest = dest.Elem()
 		}
+		if field.Accessor {
+			dest, set = setterDest(dest, field)
+			break
+		}
 		dest = dest.Field(field.Idx)
 		if field.NextStruct == nil {
 			break
@@ -489,5 +1068,5 @@ func (c *convertor) setValueByPath(dest, val reflect.Value, field typeField, src
 	if dest.Kind() != reflect.Ptr && dest.CanAddr() {
 		dest = dest.Addr()
 	}
-	return c.convert(val, dest, srcFinalStruct, field.FinalStruct)
+	return dest, field, set
 }
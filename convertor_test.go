@@ -1,9 +1,13 @@
 package convertor
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"testing"
 
@@ -89,21 +93,24 @@ func TestGetCacheStruct(t *testing.T) {
 		TypeB
 	}
 	s = getCacheStruct(reflect.TypeOf(TypeE{}), nil)
-	assert.Equal(t, s.err, fmt.Errorf("ambiguous field FieldBB"))
+	assert.True(t, errors.Is(s.err, ErrAmbiguousField))
+	assert.EqualError(t, s.err, "ambiguous field FieldBB")
 
 	type TypeF struct {
 		FieldD string
 		FieldE string `convertor:"FieldD"`
 	}
 	s = getCacheStruct(reflect.TypeOf(TypeF{}), nil)
-	assert.Equal(t, s.err, fmt.Errorf("conflict field name and tag: FieldD"))
+	assert.True(t, errors.Is(s.err, ErrConflictFieldName))
+	assert.EqualError(t, s.err, "conflict field name and tag: FieldD")
 	type TypeAA struct {
 		FieldA  *TypeAA
 		FieldBB string
 		*TypeAA
 	}
 	s = getCacheStruct(reflect.TypeOf(TypeAA{}), nil)
-	assert.Equal(t, s.err, fmt.Errorf("circle struct rely: %s", reflect.TypeOf(&TypeAA{})))
+	assert.True(t, errors.Is(s.err, ErrCircleStruct))
+	assert.EqualError(t, s.err, fmt.Sprintf("circle struct rely: %s", reflect.TypeOf(&TypeAA{})))
 }
 
 func TestConvert(t *testing.T) {
@@ -262,14 +269,14 @@ func TestConvertFunc(t *testing.T) {
 	}
 	err := Convert(TypeCC{}, &TypeDD{})
 	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "dest has no field to receive src field FieldA(string)")
+	assert.Equal(t, err.Error(), "Field.FieldA: dest has no field to receive src field FieldA(string)")
 	err = Convert(TypeDD{}, &TypeEE{})
 	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "src has no field FieldA(string) convert to dest")
+	assert.Equal(t, err.Error(), "Field.FieldA: src has no field FieldA(string) convert to dest")
 	err = Convert(TypeEE{}, &TypeCC{})
-	assert.Equal(t, err.Error(), "dest has no field to receive src field Field1(convertor.TypeB)")
+	assert.Equal(t, err.Error(), "Field1: dest has no field to receive src field Field1(convertor.TypeB)")
 	err = Convert(TypeCC{}, &TypeEE{})
-	assert.Equal(t, err.Error(), "src has no field Field1(convertor.TypeB) convert to dest")
+	assert.Equal(t, err.Error(), "Field1: src has no field Field1(convertor.TypeB) convert to dest")
 	err = DestNotExistFieldIgnoreConvertor.Convert(TypeEE{}, &TypeFF{})
 	assert.Nil(t, err)
 	err = SrcNotExistFieldIgnoreConvertor.Convert(TypeFF{}, &TypeEE{})
@@ -293,7 +300,7 @@ func TestConvertFunc(t *testing.T) {
 	assert.Equal(t, err, ErrDestinationNotPointer)
 	i := new(int)
 	err = Convert("aaa", i)
-	assert.Equal(t, err, fmt.Errorf("type %s is not convertiable to type %s", reflect.TypeOf(""), reflect.TypeOf(i)))
+	assert.EqualError(t, err, fmt.Sprintf("type %s is not convertiable to type %s", reflect.TypeOf(""), reflect.TypeOf(i)))
 }
 
 func TestOption(t *testing.T) {
@@ -342,7 +349,7 @@ func TestConvertInterface(t *testing.T) {
 	assert.Equal(t, b.P.FullName(), "aaa bbb")
 	a := &TypeA{}
 	err = Convert(*b, a)
-	assert.Equal(t, err, fmt.Errorf("type convertor.Peopler is not convertiable to type *convertor.People"))
+	assert.EqualError(t, err, "P: type convertor.Peopler is not convertiable to type *convertor.People")
 }
 
 func TestRegisterConvertorFuncError(t *testing.T) {
@@ -352,3 +359,253 @@ func TestRegisterConvertorFuncError(t *testing.T) {
 	assert.Equal(t, BadConvertFuncDestTypeNotPointer, registerConvertFunc(nil, func(src int, dest float64) error { return nil }))
 	assert.Equal(t, BadConvertFuncOut, registerConvertFunc(nil, func(src int, dest *float64) {}))
 }
+
+func TestFieldNameNormalization(t *testing.T) {
+	type Src struct {
+		UserID    int
+		User_Name string
+	}
+	type Dest struct {
+		UserId   int
+		Username string `convertor:"username"`
+	}
+	err := Convert(Src{}, &Dest{})
+	assert.EqualError(t, err, "UserID: dest has no field to receive src field UserID(int)")
+	err = Convert(Src{}, &Dest{}, OptionCaseInsensitiveFieldName())
+	assert.EqualError(t, err, "User_Name: dest has no field to receive src field user_name(string)")
+
+	var d Dest
+	err = Convert(Src{UserID: 10, User_Name: "name"}, &d, OptionNormalizedFieldName())
+	assert.Nil(t, err)
+	assert.Equal(t, Dest{UserId: 10, Username: "name"}, d)
+
+	type Conflict struct {
+		UserID int
+		UserId int
+	}
+	err = Convert(Conflict{}, &Dest{}, OptionCaseInsensitiveFieldName())
+	assert.EqualError(t, err, "conflict field name after normalization: UserID and UserId")
+	err = Convert(Conflict{}, &Dest{})
+	assert.EqualError(t, err, "UserID: dest has no field to receive src field UserID(int)")
+}
+
+func TestConvertFlattenFieldOrder(t *testing.T) {
+	type Inner struct {
+		B int
+		A string
+	}
+	type Middle struct {
+		Inner
+		D int
+		C string
+	}
+	type Src struct {
+		Middle
+	}
+	type Dest struct {
+		A string
+		B int
+		C string
+		D int
+	}
+	var d Dest
+	err := Convert(Src{Middle{Inner: Inner{B: 1, A: "a"}, D: 2, C: "c"}}, &d)
+	assert.Nil(t, err)
+	assert.Equal(t, Dest{A: "a", B: 1, C: "c", D: 2}, d)
+}
+
+type Celsius float64
+
+func (c Celsius) String() string {
+	return strconv.FormatFloat(float64(c), 'f', 1, 64) + "C"
+}
+
+type Kelvin float64
+
+func (k Kelvin) String() string {
+	return strconv.FormatFloat(float64(k), 'f', 1, 64) + "K"
+}
+
+func TestInterfaceConvertFunc(t *testing.T) {
+	type Reading struct {
+		Temp  Celsius
+		Other Kelvin
+		Err   error
+	}
+	type ReadingDTO struct {
+		Temp  string
+		Other string
+		Err   string
+	}
+	c, err := NewConvertor(
+		OptionConvertFunc(func(src fmt.Stringer, dest *string) error {
+			*dest = src.String()
+			return nil
+		}),
+		OptionConvertFunc(func(src error, dest *string) error {
+			*dest = "error: " + src.Error()
+			return nil
+		}),
+		OptionConvertFunc(func(src Kelvin, dest *string) error {
+			*dest = "kelvin"
+			return nil
+		}),
+	)
+	assert.Nil(t, err)
+	var dto ReadingDTO
+	err = c.Convert(Reading{Temp: 21.5, Other: 3, Err: errors.New("bad")}, &dto)
+	assert.Nil(t, err)
+	// exact func of Kelvin is prior to fmt.Stringer
+	assert.Equal(t, ReadingDTO{Temp: "21.5C", Other: "kelvin", Err: "error: bad"}, dto)
+	// nil interface field is skipped
+	dto = ReadingDTO{}
+	err = c.Convert(Reading{Temp: 1}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, ReadingDTO{Temp: "1.0C", Other: "kelvin"}, dto)
+
+	// cached resolution is updated by later global register
+	type UnitDTO struct {
+		Value string
+	}
+	var unit UnitDTO
+	err = c.Convert(struct{ Value Unit }{}, &unit)
+	assert.True(t, errors.Is(err, ErrNotConvertible))
+	saved := convertFuncsType{exact: maps.Clone(convertFuncs.exact), ifaces: slices.Clone(convertFuncs.ifaces)}
+	t.Cleanup(func() { // global func shouldn't leak to other tests
+		convertFuncs = saved
+		convertFuncsGen.Add(1)
+	})
+	RegisterConvertFunc(func(src interface{ Unit() string }, dest *string) error {
+		*dest = src.Unit()
+		return nil
+	})
+	err = c.Convert(struct{ Value Unit }{}, &unit)
+	assert.Nil(t, err)
+	assert.Equal(t, "m", unit.Value)
+}
+
+type Unit struct{}
+
+func (Unit) Unit() string {
+	return "m"
+}
+
+type ptrErr struct {
+	msg string
+}
+
+func (e *ptrErr) Error() string {
+	return e.msg
+}
+
+type ptrStringer struct {
+	name string
+}
+
+func (s *ptrStringer) String() string {
+	return s.name
+}
+
+func TestInterfaceConvertFuncPointerReceiver(t *testing.T) {
+	type Src struct {
+		Err   *ptrErr
+		Path  *fs.PathError
+		Name  *ptrStringer
+		Exact *Celsius
+	}
+	type Dest struct {
+		Err   string
+		Path  string
+		Name  string
+		Exact string
+	}
+	opts := []Option{
+		OptionConvertFunc(func(src error, dest *string) error {
+			*dest = "error: " + src.Error()
+			return nil
+		}),
+		OptionConvertFunc(func(src fmt.Stringer, dest *string) error {
+			*dest = "stringer: " + src.String()
+			return nil
+		}),
+		OptionConvertFunc(func(src Celsius, dest *string) error {
+			*dest = "celsius"
+			return nil
+		}),
+	}
+	celsius := Celsius(1)
+	src := Src{
+		Err:   &ptrErr{msg: "bad"},
+		Path:  &fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist},
+		Name:  &ptrStringer{name: "n"},
+		Exact: &celsius,
+	}
+	var dest Dest
+	err := Convert(src, &dest, opts...)
+	assert.Nil(t, err)
+	// exact func of Celsius is prior to fmt.Stringer of *Celsius
+	assert.Equal(t, Dest{Err: "error: bad", Path: "error: open a: file does not exist", Name: "stringer: n", Exact: "celsius"}, dest)
+
+	report, err := Check(reflect.TypeOf(Src{}), reflect.TypeOf(Dest{}), opts...)
+	assert.Nil(t, err)
+	for _, m := range report.Mappings {
+		assert.Equal(t, MappingFunc, m.Kind, m.DestPath)
+	}
+}
+
+func TestConvertFuncWithConvertor(t *testing.T) {
+	type Item struct {
+		Price int64
+		Count int
+	}
+	type Order struct {
+		ID    int
+		Items []Item
+		Note  string
+	}
+	type ItemDTO struct {
+		Price int32
+		Count int32
+		Total int64
+	}
+	type OrderDTO struct {
+		ID    int
+		Items []ItemDTO
+	}
+	c, err := NewConvertor(
+		OptionDestNotExistFieldIgnore(),
+		OptionSrcNotExistFieldIgnore(),
+		OptionConvertFunc(func(c Convertor, src Item, dest *ItemDTO) error {
+			if err := ConvertDefault(c, src, dest); err != nil {
+				return err
+			}
+			dest.Total = src.Price * int64(src.Count)
+			return nil
+		}),
+		OptionConvertFunc(func(c Convertor, src Order, dest *OrderDTO) error {
+			if src.ID < 0 {
+				return errors.New("negative id")
+			}
+			return ConvertDefault(c, src, dest)
+		}),
+	)
+	assert.Nil(t, err)
+	var dto OrderDTO
+	// options of c ignore the missing fields
+	err = c.Convert(Order{ID: 1, Items: []Item{{Price: 2, Count: 3}}, Note: "note"}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, OrderDTO{ID: 1, Items: []ItemDTO{{Price: 2, Count: 3, Total: 6}}}, dto)
+	err = c.Convert(struct{ Orders []Order }{Orders: []Order{{ID: -1}}}, &struct{ Orders []OrderDTO }{})
+	assert.EqualError(t, err, "Orders[0]: negative id")
+
+	// errors inside ConvertDefault keep the path
+	c, err = NewConvertor(OptionConvertFunc(func(c Convertor, src Order, dest *OrderDTO) error {
+		return ConvertDefault(c, src, dest)
+	}))
+	assert.Nil(t, err)
+	err = c.Convert(struct{ Order Order }{}, &struct{ Order OrderDTO }{})
+	assert.EqualError(t, err, "Order.Note: dest has no field to receive src field Note(string)")
+	assert.True(t, errors.Is(err, ErrDestFieldMissing))
+
+	assert.Equal(t, BadConvertFuncInCount, registerConvertFunc(nil, func(c int, src Item, dest *ItemDTO) error { return nil }))
+}
@@ -0,0 +1,139 @@
+// Package convertortest provides helpers for testing conversions of convertor
+package convertortest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cdongyang/convertor"
+)
+
+var update = flag.Bool("convertortest.update", false, "update golden files of AssertGoldenMapping")
+
+// AssertConvertible check that the type of src is convertible to the type of dest by convertor.Check,
+// and convert src to a new value of dest type, src and dest may be zero values like Src{} and Dest{}
+func AssertConvertible(t testing.TB, src, dest interface{}, opts ...convertor.Option) bool {
+	t.Helper()
+	destType := indirectType(reflect.TypeOf(dest))
+	if _, err := convertor.Check(reflect.TypeOf(src), destType, opts...); err != nil {
+		t.Errorf("%v is not convertible to %v:\n%v", reflect.TypeOf(src), destType, err)
+		return false
+	}
+	if err := convertor.Convert(src, reflect.New(destType).Interface(), opts...); err != nil {
+		t.Errorf("convert %v to %v: %v", reflect.TypeOf(src), destType, err)
+		return false
+	}
+	return true
+}
+
+// AssertRoundTrip convert value to intermediate and back to a new value of the same type,
+// the result should be deep equal to value, intermediate is a pointer and keeps the converted value,
+// if value is a pointer the value it points to is compared
+func AssertRoundTrip(t testing.TB, value, intermediate interface{}, opts ...convertor.Option) bool {
+	t.Helper()
+	if err := convertor.Convert(value, intermediate, opts...); err != nil {
+		t.Errorf("convert %T to %T: %v", value, intermediate, err)
+		return false
+	}
+	want := reflect.ValueOf(value)
+	for want.Kind() == reflect.Ptr && !want.IsNil() {
+		want = want.Elem()
+	}
+	back := reflect.New(want.Type())
+	if err := convertor.Convert(reflect.ValueOf(intermediate).Elem().Interface(), back.Interface(), opts...); err != nil {
+		t.Errorf("convert %T back to %v: %v", intermediate, want.Type(), err)
+		return false
+	}
+	if got := back.Elem().Interface(); !reflect.DeepEqual(want.Interface(), got) {
+		t.Errorf("round trip through %T changes value:\nexpected: %+v\nactual  : %+v", intermediate, want.Interface(), got)
+		return false
+	}
+	return true
+}
+
+// AssertGoldenMapping compare the field mapping resolved by convertor.Check with the golden file testdata/<name>.golden,
+// run tests with -convertortest.update to write the golden file
+func AssertGoldenMapping(t testing.TB, name string, src, dest interface{}, opts ...convertor.Option) bool {
+	t.Helper()
+	report, err := convertor.Check(reflect.TypeOf(src), indirectType(reflect.TypeOf(dest)), opts...)
+	if report == nil {
+		t.Errorf("check mapping %s: %v", name, err)
+		return false
+	}
+	got := []byte(report.String())
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Errorf("update golden file %s: %v", path, err)
+			return false
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Errorf("update golden file %s: %v", path, err)
+			return false
+		}
+		return true
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("read golden file %s: %v, run with -convertortest.update to create it", path, err)
+		return false
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("mapping %s differs from golden file %s:\nexpected:\n%s\nactual:\n%s", name, path, want, got)
+		return false
+	}
+	return true
+}
+
+// FuzzRoundTrip fuzz AssertRoundTrip with values of the type of value filled by convertor.Fill from the fuzzed seed,
+// value and intermediate are values or pointers of their types, e.g.
+//
+//	func FuzzUserRow(f *testing.F) {
+//		convertortest.FuzzRoundTrip(f, User{}, UserRow{})
+//	}
+func FuzzRoundTrip(f *testing.F, value, intermediate interface{}, opts ...convertor.Option) {
+	f.Helper()
+	valueType := indirectType(reflect.TypeOf(value))
+	intermediateType := indirectType(reflect.TypeOf(intermediate))
+	for seed := int64(0); seed < 4; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		val := reflect.New(valueType)
+		if err := convertor.Fill(val.Interface(), seed, opts...); err != nil {
+			t.Fatalf("fill %v: %v", valueType, err)
+		}
+		AssertRoundTrip(t, val.Elem().Interface(), reflect.New(intermediateType).Interface(), opts...)
+	})
+}
+
+// FuzzConvert fuzz converting values of the type of src filled by convertor.Fill from the fuzzed seed to the type of dest,
+// src and dest are values or pointers of their types
+func FuzzConvert(f *testing.F, src, dest interface{}, opts ...convertor.Option) {
+	f.Helper()
+	srcType := indirectType(reflect.TypeOf(src))
+	destType := indirectType(reflect.TypeOf(dest))
+	for seed := int64(0); seed < 4; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		val := reflect.New(srcType)
+		if err := convertor.Fill(val.Interface(), seed, opts...); err != nil {
+			t.Fatalf("fill %v: %v", srcType, err)
+		}
+		if err := convertor.Convert(val.Elem().Interface(), reflect.New(destType).Interface(), opts...); err != nil {
+			t.Errorf("convert %+v to %v: %v", val.Elem().Interface(), destType, err)
+		}
+	})
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
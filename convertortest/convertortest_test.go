@@ -0,0 +1,110 @@
+package convertortest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cdongyang/convertor"
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type User struct {
+	ID      int64
+	Name    string
+	Address Address
+	Tags    []string
+}
+
+type UserRow struct {
+	ID          int32
+	Name        string
+	AddressCity string `convertor:"Address.City"`
+	Street      string `convertor:"Address.Street"`
+	Tags        []string
+}
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertConvertible(t *testing.T) {
+	AssertConvertible(t, User{}, UserRow{})
+	AssertConvertible(t, User{}, &UserRow{})
+
+	r := &recorder{TB: t}
+	assert.False(t, AssertConvertible(r, User{}, struct{ ID int }{}))
+	assert.Equal(t, []string{"convertortest.User is not convertible to struct { ID int }:\n" +
+		"Address: dest has no field to receive src field Address(convertortest.Address)\n" +
+		"Name: dest has no field to receive src field Name(string)\n" +
+		"Tags: dest has no field to receive src field Tags([]string)"}, r.errors)
+	assert.True(t, AssertConvertible(r, User{}, struct{ ID int }{}, convertor.OptionDestNotExistFieldIgnore()))
+}
+
+func TestAssertRoundTrip(t *testing.T) {
+	user := User{ID: 1, Name: "name", Address: Address{City: "city", Street: "street"}, Tags: []string{"a"}}
+	var row UserRow
+	AssertRoundTrip(t, user, &row)
+	assert.Equal(t, UserRow{ID: 1, Name: "name", AddressCity: "city", Street: "street", Tags: []string{"a"}}, row)
+	// pointer value is compared by the value it points to
+	row = UserRow{}
+	assert.True(t, AssertRoundTrip(t, &user, &row))
+
+	r := &recorder{TB: t}
+	user.ID = 1 << 40
+	assert.False(t, AssertRoundTrip(r, user, &row))
+	assert.Equal(t, 1, len(r.errors))
+	assert.Contains(t, r.errors[0], "round trip through *convertortest.UserRow changes value")
+}
+
+func TestAssertGoldenMapping(t *testing.T) {
+	AssertGoldenMapping(t, "user_row", User{}, UserRow{})
+	if *update {
+		return
+	}
+
+	r := &recorder{TB: t}
+	assert.False(t, AssertGoldenMapping(r, "user_row", User{}, User{}))
+	assert.Contains(t, r.errors[0], "mapping user_row differs from golden file testdata/user_row.golden")
+	r.errors = nil
+	assert.False(t, AssertGoldenMapping(r, "not_exist", User{}, UserRow{}))
+	assert.Contains(t, r.errors[0], "run with -convertortest.update to create it")
+}
+
+type UserDTO struct {
+	ID      uint32
+	Name    string
+	Address struct {
+		City   string
+		Street string
+	}
+	Tags []string
+}
+
+func FuzzUserRow(f *testing.F) {
+	FuzzRoundTrip(f, User{}, UserRow{})
+}
+
+func FuzzUserDTO(f *testing.F) {
+	FuzzConvert(f, User{}, &UserDTO{})
+}
+
+func FuzzUserRowPointer(f *testing.F) {
+	FuzzRoundTrip(f, &User{}, &UserRow{})
+}
+
+func FuzzUserDTOPointer(f *testing.F) {
+	FuzzConvert(f, &User{}, &UserDTO{})
+}
@@ -0,0 +1,177 @@
+package convertor
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConvertError is the error of converting src to dest with the field path where it happened,
+// errors.As(err, new(*ConvertError)) works for every conversion error returned by Convert,
+// errors of bad arguments like ErrNilSource, ErrNilDestination and ErrDestinationNotPointer are returned as they are
+type ConvertError struct {
+	Path     string       // go field path from the converted value like Orders[3].Items[0].Price, empty for the value itself
+	Field    string       // name of the last field in Path
+	SrcType  reflect.Type // type of src value or field, nil if src field is missing
+	DestType reflect.Type // type of dest value or field, nil if dest field is missing
+	Err      error
+}
+
+func (e *ConvertError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ConvertError) Unwrap() error {
+	return e.Err
+}
+
+func newConvertError(srcType, destType reflect.Type, err error) error {
+	switch err.(type) {
+	case *ConvertError, ConvertErrors:
+		return err
+	}
+	return &ConvertError{SrcType: indirectType(srcType), DestType: indirectType(destType), Err: err}
+}
+
+// ConvertErrors is all the failures of a conversion with OptionCollectErrors, sorted by path
+type ConvertErrors []*ConvertError
+
+// Error join messages of all errors by newline like errors.Join
+func (errs ConvertErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (errs ConvertErrors) Unwrap() []error {
+	list := make([]error, len(errs))
+	for i, err := range errs {
+		list[i] = err
+	}
+	return list
+}
+
+// errorCollector return the first error or gather all errors if OptionCollectErrors is set
+type errorCollector struct {
+	collect bool
+	errs    ConvertErrors
+}
+
+func (c *convertor) newErrorCollector() *errorCollector {
+	return &errorCollector{collect: c.opts.collectErrors}
+}
+
+// add return err back if errors are not collected
+func (ec *errorCollector) add(err error) error {
+	if !ec.collect {
+		return err
+	}
+	switch e := err.(type) {
+	case ConvertErrors:
+		ec.errs = append(ec.errs, e...)
+	case *ConvertError:
+		ec.errs = append(ec.errs, e)
+	default:
+		ec.errs = append(ec.errs, &ConvertError{Err: err})
+	}
+	return nil
+}
+
+func (ec *errorCollector) err() error {
+	if len(ec.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(ec.errs, func(i, j int) bool {
+		return comparePath(ec.errs[i].Path, ec.errs[j].Path) < 0
+	})
+	return ec.errs
+}
+
+// comparePath compare paths by segments, slice indexes are compared as numbers
+func comparePath(a, b string) int {
+	as, bs := splitPath(a), splitPath(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		ai, aErr := strconv.Atoi(strings.Trim(as[i], "[]"))
+		bi, bErr := strconv.Atoi(strings.Trim(bs[i], "[]"))
+		if aErr == nil && bErr == nil && strings.HasPrefix(as[i], "[") && strings.HasPrefix(bs[i], "[") {
+			return ai - bi
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+	return len(as) - len(bs)
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, name := range strings.Split(path, ".") {
+		start := 0
+		for k := 1; k < len(name); k++ {
+			if name[k] == '[' {
+				segments = append(segments, name[start:k])
+				start = k
+			}
+		}
+		segments = append(segments, name[start:])
+	}
+	return segments
+}
+
+// fieldPath return the go field path of field for error path, the match name is kept in messages only
+func fieldPath(field typeField) string {
+	if field.Accessor { // Origin is the method name with Get or Set prefix
+		return field.Origin[len("Get"):]
+	}
+	if field.Origin == "" {
+		return field.Name
+	}
+	return field.Origin
+}
+
+// wrapPath add field path or slice index like [3] to the front of error path,
+// err is wrapped to ConvertError with srcType and destType if it's not
+func wrapPath(err error, segment string, srcType, destType reflect.Type) error {
+	if errs, ok := err.(ConvertErrors); ok {
+		wrapped := make(ConvertErrors, len(errs))
+		for i, e := range errs {
+			wrapped[i] = wrapPath(e, segment, srcType, destType).(*ConvertError)
+		}
+		return wrapped
+	}
+	ce, ok := err.(*ConvertError)
+	if !ok {
+		ce = &ConvertError{SrcType: indirectType(srcType), DestType: indirectType(destType), Err: err}
+	}
+	wrapped := *ce
+	switch {
+	case wrapped.Path == "":
+		wrapped.Path = segment
+	case strings.HasPrefix(wrapped.Path, "["):
+		wrapped.Path = segment + wrapped.Path
+	default:
+		wrapped.Path = segment + "." + wrapped.Path
+	}
+	if wrapped.Field == "" && !strings.HasPrefix(segment, "[") {
+		wrapped.Field = segment[strings.LastIndexByte(segment, '.')+1:]
+	}
+	return &wrapped
+}
+
+func destFieldMissing(srcField typeField, destStruct *typeStruct) error {
+	err := fmt.Errorf("%w to receive src field %s(%v)%s", ErrDestFieldMissing, srcField.Name, srcField.Type, destStruct.explain(srcField.Name))
+	return wrapPath(err, fieldPath(srcField), srcField.Type, nil)
+}
+
+func srcFieldMissing(destField typeField, srcStruct *typeStruct) error {
+	err := fmt.Errorf("%w %s(%v) convert to dest%s", ErrSrcFieldMissing, destField.Name, destField.Type, srcStruct.explain(destField.Name))
+	return wrapPath(err, fieldPath(destField), nil, destField.Type)
+}
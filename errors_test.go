@@ -0,0 +1,223 @@
+package convertor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertError(t *testing.T) {
+	type Item struct {
+		Price string
+	}
+	type Order struct {
+		Items []Item
+	}
+	type User struct {
+		Orders []Order
+	}
+	type ItemDTO struct {
+		Price int
+	}
+	type OrderDTO struct {
+		Items []ItemDTO
+	}
+	type UserDTO struct {
+		Orders []OrderDTO
+	}
+	user := User{Orders: make([]Order, 4)}
+	user.Orders[3].Items = []Item{{Price: "1"}}
+	err := Convert(user, &UserDTO{})
+	assert.EqualError(t, err, "Orders[3].Items[0].Price: type string is not convertiable to type *int")
+	var ce *ConvertError
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, "Orders[3].Items[0].Price", ce.Path)
+	assert.Equal(t, "Price", ce.Field)
+	assert.Equal(t, reflect.TypeOf(""), ce.SrcType)
+	assert.Equal(t, reflect.TypeOf(0), ce.DestType)
+
+	// errors of convert func is wrapped
+	errBad := errors.New("bad price")
+	c, err := NewConvertor(OptionConvertFunc(func(src Item, dest *ItemDTO) error {
+		return errBad
+	}))
+	assert.Nil(t, err)
+	err = c.Convert(user, &UserDTO{})
+	assert.EqualError(t, err, "Orders[3].Items[0]: bad price")
+	assert.True(t, errors.Is(err, errBad))
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, "Items", ce.Field)
+	assert.Equal(t, reflect.TypeOf(Item{}), ce.SrcType)
+
+	// missing field has no type on the missing side
+	err = Convert(struct{ Orders []struct{ ID int } }{Orders: make([]struct{ ID int }, 1)}, &UserDTO{})
+	assert.EqualError(t, err, "Orders[0].ID: dest has no field to receive src field ID(int)")
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, reflect.TypeOf(0), ce.SrcType)
+	assert.Nil(t, ce.DestType)
+
+	// path is made of go field names, the match name is in the message only
+	type Record struct {
+		CreatedBy string
+		UserID    string
+	}
+	type AuditDTO struct {
+		CreatedBy int
+	}
+	type RecordDTO struct {
+		AuditDTO
+		UserID int
+	}
+	err = Convert(Record{}, &RecordDTO{}, OptionNamingStrategy(SnakeCase), OptionCollectErrors())
+	assert.EqualError(t, err, "AuditDTO.CreatedBy: type string is not convertiable to type *int\nUserID: type string is not convertiable to type *int")
+	var errs ConvertErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, "AuditDTO.CreatedBy", errs[0].Path)
+	assert.Equal(t, "CreatedBy", errs[0].Field)
+	assert.Equal(t, "UserID", errs[1].Field)
+	err = Convert(struct{ UserID int }{}, &struct{ Name string }{}, OptionNamingStrategy(SnakeCase), OptionSrcNotExistFieldIgnore())
+	assert.EqualError(t, err, "UserID: dest has no field to receive src field user_id(int)")
+}
+
+func TestSentinelErrors(t *testing.T) {
+	type Src struct {
+		ID   int
+		Name string
+	}
+	type Dest struct {
+		ID   int
+		Mail string
+	}
+	err := Convert(Src{}, &Dest{})
+	assert.True(t, errors.Is(err, ErrSrcFieldMissing))
+	assert.EqualError(t, err, "Mail: src has no field Mail(string) convert to dest")
+	err = Convert(Src{}, &Dest{}, OptionSrcNotExistFieldIgnore())
+	assert.True(t, errors.Is(err, ErrDestFieldMissing))
+	assert.EqualError(t, err, "Name: dest has no field to receive src field Name(string)")
+	err = Convert(struct{ ID string }{}, &Dest{}, OptionSrcNotExistFieldIgnore())
+	assert.True(t, errors.Is(err, ErrNotConvertible))
+	assert.EqualError(t, err, "ID: type string is not convertiable to type *int")
+	type Conflict struct {
+		ID   int
+		Name string `convertor:"ID"`
+	}
+	err = Convert(Conflict{}, &Dest{})
+	assert.True(t, errors.Is(err, ErrConflictFieldName))
+	assert.False(t, errors.Is(err, ErrAmbiguousField))
+}
+
+func TestCollectErrors(t *testing.T) {
+	type Item struct {
+		Price string
+		Count int
+	}
+	type Order struct {
+		ID    int
+		Items []Item
+		Note  string
+	}
+	type ItemDTO struct {
+		Price int
+		Count int
+	}
+	type OrderDTO struct {
+		ID    int
+		Items []ItemDTO
+		Memo  string
+	}
+	items := make([]Item, 11)
+	items[10].Price = "10"
+	items[2].Price = "2"
+	var dto OrderDTO
+	err := Convert(Order{ID: 1, Items: items}, &dto, OptionCollectErrors())
+	var errs ConvertErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.EqualError(t, err, "Items[0].Price: type string is not convertiable to type *int\n"+
+		"Items[1].Price: type string is not convertiable to type *int\n"+
+		"Items[2].Price: type string is not convertiable to type *int\n"+
+		"Items[3].Price: type string is not convertiable to type *int\n"+
+		"Items[4].Price: type string is not convertiable to type *int\n"+
+		"Items[5].Price: type string is not convertiable to type *int\n"+
+		"Items[6].Price: type string is not convertiable to type *int\n"+
+		"Items[7].Price: type string is not convertiable to type *int\n"+
+		"Items[8].Price: type string is not convertiable to type *int\n"+
+		"Items[9].Price: type string is not convertiable to type *int\n"+
+		"Items[10].Price: type string is not convertiable to type *int\n"+
+		"Memo: src has no field Memo(string) convert to dest\n"+
+		"Note: dest has no field to receive src field Note(string)")
+	assert.True(t, errors.Is(err, ErrNotConvertible))
+	assert.True(t, errors.Is(err, ErrSrcFieldMissing))
+	assert.True(t, errors.Is(err, ErrDestFieldMissing))
+	// other fields are converted
+	assert.Equal(t, 1, dto.ID)
+	assert.Equal(t, 11, len(dto.Items))
+
+	err = Convert(Order{ID: 1}, &dto, OptionCollectErrors(), OptionSrcNotExistFieldIgnore(), OptionDestNotExistFieldIgnore())
+	assert.Nil(t, err)
+}
+
+func TestNeverPanic(t *testing.T) {
+	type Dest struct {
+		Name string
+	}
+	var d Dest
+	assert.Equal(t, ErrNilSource, Convert(nil, &d))
+	assert.Equal(t, ErrNilSource, Convert((*struct{ Name string })(nil), &d))
+	for _, err := range ConvertSeq[*struct{ Name string }, Dest](nil, func(yield func(*struct{ Name string }) bool) {
+		yield(nil)
+	}) {
+		assert.Equal(t, ErrNilSource, err)
+	}
+
+	// panic of convert func is returned with field path
+	type Inner struct {
+		Value int
+	}
+	type InnerDTO struct {
+		Value string
+	}
+	c, err := NewConvertor(OptionConvertFunc(func(src Inner, dest *InnerDTO) error {
+		panic("boom")
+	}))
+	assert.Nil(t, err)
+	err = c.Convert(struct{ Items []Inner }{Items: []Inner{{}}}, &struct{ Items []InnerDTO }{})
+	assert.True(t, errors.Is(err, ErrConvertPanic))
+	assert.EqualError(t, err, "Items[0]: panic while converting in func func(convertor.Inner, *convertor.InnerDTO) error: boom")
+
+	c, err = NewConvertor(OptionFieldMapping(Inner{}, InnerDTO{}, ComputeField([]string{"Value"}, []string{"Value"}, func(v int) (string, error) {
+		panic("boom")
+	})))
+	assert.Nil(t, err)
+	err = c.Convert(Inner{}, &InnerDTO{})
+	assert.True(t, errors.Is(err, ErrConvertPanic))
+	assert.EqualError(t, err, "Value: panic while converting in func func(int) (string, error): boom")
+
+	// panic of predicate keeps the field path
+	type InnerCopy struct {
+		Value int
+	}
+	c, err = NewConvertor(OptionFieldMapping(Inner{}, InnerCopy{}, FieldCondition("Value", func(src Inner, v int) bool {
+		panic("boom")
+	})))
+	assert.Nil(t, err)
+	err = c.Convert(Inner{}, &InnerCopy{})
+	assert.True(t, errors.Is(err, ErrConvertPanic))
+	assert.EqualError(t, err, "Value: panic while converting in func func(convertor.Inner, int) bool: boom")
+
+	// nil interfaces in fields and slices are skipped like nil pointers
+	type Src struct {
+		P     Peopler
+		Items []interface{}
+	}
+	type PeopleDest struct {
+		P     *People
+		Items []string
+	}
+	var pd PeopleDest
+	err = Convert(Src{Items: []interface{}{nil}}, &pd)
+	assert.Nil(t, err)
+	assert.Nil(t, pd.P)
+	assert.Equal(t, []string{""}, pd.Items)
+}
@@ -0,0 +1,119 @@
+package convertor
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+const fillLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+/*
+Fill fill every field in the field tree of dest with pseudo-random values generated from seed,
+the same seed fills the same values, e.g. fuzz conversion between two types:
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		var user User
+		if err := convertor.Fill(&user, seed); err != nil {
+			t.Fatal(err)
+		}
+		...
+	})
+
+numbers are non-negative and fit in half of the type bits so they convert between int and uint types,
+slices get 1 to 3 elements, every element of arrays is filled, nil pointers are allocated except recursive ones,
+dest may be a pointer to nil pointer like Fill(&p, seed) with p of *User, fields not in the field tree such as
+ignored fields, getter and setter methods, maps and interfaces are left unchanged
+*/
+func Fill(dest interface{}, seed int64, opts ...Option) error {
+	destVal, err := destValue(dest)
+	if err != nil {
+		return err
+	}
+	cv, err := NewConvertor(opts...)
+	if err != nil {
+		return err
+	}
+	c := cv.(*convertor)
+	return c.fill(destVal, c.destCfg.getCacheStruct(destVal.Type(), nil), rand.New(rand.NewSource(seed)), map[reflect.Type]bool{})
+}
+
+// fill fill the value which dest points to, visiting is struct types on the path,
+// pointers and slices of them are left nil to stop at recursive types like linked list
+func (c *convertor) fill(dest reflect.Value, destStruct *typeStruct, r *rand.Rand, visiting map[reflect.Type]bool) error {
+	if destStruct == nil {
+		destStruct = c.destCfg.getCacheStruct(dest.Type(), nil)
+	}
+	if destStruct.err != nil {
+		return newConvertError(nil, dest.Type(), destStruct.err)
+	}
+	val := dest.Elem()
+	switch val.Kind() {
+	case reflect.Bool:
+		val.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val.SetInt(r.Int63() >> (64 - val.Type().Bits()/2))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val.SetUint(uint64(r.Int63() >> (64 - val.Type().Bits()/2)))
+	case reflect.Float32, reflect.Float64:
+		// float32 value is kept exactly by float64
+		val.SetFloat(float64(float32(r.Float64() * 1000)))
+	case reflect.String:
+		b := make([]byte, 1+r.Intn(8))
+		for i := range b {
+			b[i] = fillLetters[r.Intn(len(fillLetters))]
+		}
+		val.SetString(string(b))
+	case reflect.Slice:
+		if visiting[indirectType(val.Type().Elem())] {
+			break
+		}
+		val.Set(reflect.MakeSlice(val.Type(), 1+r.Intn(3), 3))
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i)
+			if elem.Kind() == reflect.Ptr {
+				elem.Set(reflect.New(elem.Type().Elem()))
+			} else {
+				elem = elem.Addr()
+			}
+			if err := c.fill(elem, destStruct.elemStruct, r, visiting); err != nil {
+				return wrapPath(err, fmt.Sprintf("[%d]", i), nil, elem.Type())
+			}
+		}
+	case reflect.Array:
+		if visiting[indirectType(val.Type().Elem())] {
+			break
+		}
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i).Addr()
+			if err := c.fill(elem, nil, r, visiting); err != nil {
+				return wrapPath(err, fmt.Sprintf("[%d]", i), nil, elem.Type())
+			}
+		}
+	case reflect.Ptr:
+		if visiting[val.Type().Elem()] {
+			break
+		}
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		// destStruct is parsed from the pointer to pointer, parse the pointer again
+		return c.fill(val, nil, r, visiting)
+	case reflect.Struct:
+		visiting[val.Type()] = true
+		defer delete(visiting, val.Type())
+		for _, field := range destStruct.fields {
+			if field.Accessor || visiting[indirectType(field.Type)] {
+				continue
+			}
+			fieldDest, last, set := getDestByPath(dest, field)
+			if err := c.fill(fieldDest, last.FinalStruct, r, visiting); err != nil {
+				return wrapPath(err, fieldPath(field), nil, field.Type)
+			}
+			if err := set(); err != nil {
+				return wrapPath(err, fieldPath(field), nil, field.Type)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package convertor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFill(t *testing.T) {
+	type Item struct {
+		Price float32
+		Count uint8
+	}
+	type Order struct {
+		ID      int64
+		Name    string
+		Paid    bool
+		Items   []*Item
+		Extra   *Item
+		Flatten struct {
+			Note string
+		} `convertor:"+"`
+		Ignored string `convertor:"-"`
+		Meta    map[string]string
+	}
+	var a, b Order
+	assert.Nil(t, Fill(&a, 1))
+	assert.Nil(t, Fill(&b, 1))
+	assert.Equal(t, a, b)
+	assert.NotZero(t, a.ID)
+	assert.True(t, a.ID < math.MaxInt32)
+	assert.NotEmpty(t, a.Name)
+	assert.NotEmpty(t, a.Items)
+	for _, item := range a.Items {
+		assert.NotNil(t, item)
+		assert.True(t, item.Count < 16)
+	}
+	assert.NotNil(t, a.Extra)
+	assert.NotEmpty(t, a.Flatten.Note)
+	assert.Empty(t, a.Ignored)
+	assert.Nil(t, a.Meta)
+
+	assert.Nil(t, Fill(&b, 2))
+	assert.NotEqual(t, a, b)
+
+	// filled value converts to a type with the same field tree
+	type ItemDTO struct {
+		Price float64
+		Count int
+	}
+	type OrderDTO struct {
+		ID    uint32
+		Name  string
+		Paid  bool
+		Items []ItemDTO
+		Extra ItemDTO
+		Note  string
+	}
+	var dto OrderDTO
+	assert.Nil(t, Convert(a, &dto, OptionDestNotExistFieldIgnore()))
+	assert.EqualValues(t, a.ID, dto.ID)
+	assert.EqualValues(t, a.Items[0].Price, dto.Items[0].Price)
+
+	assert.Equal(t, ErrDestinationNotPointer, Fill(a, 1))
+	assert.Equal(t, ErrNilDestination, Fill((*Order)(nil), 1))
+	type Node struct {
+		Value    int
+		Next     *Node
+		Children []Node
+	}
+	var node Node
+	assert.Nil(t, Fill(&node, 1))
+	assert.NotZero(t, node.Value)
+	assert.Nil(t, node.Next)
+	assert.Nil(t, node.Children)
+
+	// nil pointer which dest points to is allocated
+	var p *Order
+	assert.Nil(t, Fill(&p, 1))
+	assert.NotNil(t, p)
+	assert.Equal(t, a, *p)
+
+	type Grid struct {
+		Cells  [3]Item
+		Points [2]*Item
+		Codes  [2]string
+	}
+	var grid Grid
+	assert.Nil(t, Fill(&grid, 1))
+	for _, cell := range grid.Cells {
+		assert.NotZero(t, cell.Price)
+	}
+	for _, point := range grid.Points {
+		assert.NotNil(t, point)
+	}
+	assert.NotEmpty(t, grid.Codes[0])
+	assert.NotEmpty(t, grid.Codes[1])
+}
@@ -0,0 +1,532 @@
+package convertor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrFieldMappingNotStruct = errors.New("field mapping type is not struct")
+)
+
+// FieldRule is a field mapping rule of a type pair, see OptionFieldMapping
+type FieldRule func(rules *pairRules) error
+
+type pairRules struct {
+	maps       [][2]string // src path, dest path
+	ignoreSrc  []string
+	ignoreDest []string
+	computes   []computeRule
+	conditions []conditionRule
+	srcType    reflect.Type
+}
+
+type conditionRule struct {
+	srcPath   string
+	predicate reflect.Value
+}
+
+type computeRule struct {
+	srcPaths  []string
+	destPaths []string
+	fn        reflect.Value
+}
+
+// MapField convert src field to dest field ignoring their names,
+// a path is field names of the field tree joined by dot like Addr.City,
+// a struct field with a mapped sub field is matched, its other sub fields are ignored
+func MapField(srcPath, destPath string) FieldRule {
+	return func(rules *pairRules) error {
+		rules.maps = append(rules.maps, [2]string{srcPath, destPath})
+		return nil
+	}
+}
+
+// IgnoreSrcField ignore src field name which has no dest field to receive,
+// use a rule of the nested type pair to ignore a nested field
+func IgnoreSrcField(name string) FieldRule {
+	return func(rules *pairRules) error {
+		rules.ignoreSrc = append(rules.ignoreSrc, name)
+		return nil
+	}
+}
+
+// IgnoreDestField ignore dest field name which has no src field to convert from
+func IgnoreDestField(name string) FieldRule {
+	return func(rules *pairRules) error {
+		rules.ignoreDest = append(rules.ignoreDest, name)
+		return nil
+	}
+}
+
+// ComputeField compute dest fields from several src fields by fn in the same conversion,
+// fn receives values of srcPaths and returns values of destPaths and an error, e.g.
+//
+//	ComputeField([]string{"FirstName", "LastName"}, []string{"FullName"}, func(first, last string) (string, error) {
+//		return first + " " + last, nil
+//	})
+//
+// a nil src value is passed as zero value, returned values are converted to dest fields,
+// all the fields are matched like MapField
+func ComputeField(srcPaths, destPaths []string, fn interface{}) FieldRule {
+	return func(rules *pairRules) error {
+		val := reflect.ValueOf(fn)
+		if val.Kind() != reflect.Func {
+			return BadConvertFuncNotFunc
+		}
+		if val.Type().NumIn() != len(srcPaths) {
+			return BadConvertFuncInCount
+		}
+		if val.Type().NumOut() != len(destPaths)+1 || !isErrorType(val.Type().Out(len(destPaths))) {
+			return BadConvertFuncOut
+		}
+		rules.computes = append(rules.computes, computeRule{srcPaths: srcPaths, destPaths: destPaths, fn: val})
+		return nil
+	}
+}
+
+// FieldCondition convert src field of srcPath only if predicate returns true,
+// predicate is like func(src SrcType, value FieldType) bool, it receives the src struct and the field value,
+// srcPath * means all fields matched by name or by MapField, e.g. copy Email only if it's verified:
+//
+//	FieldCondition("Email", func(src User, email string) bool { return src.Verified })
+//
+// a nil value is passed as zero value, the field type or its elem type should be assignable to FieldType,
+// use interface{} as FieldType to receive any field
+func FieldCondition(srcPath string, predicate interface{}) FieldRule {
+	return func(rules *pairRules) error {
+		val := reflect.ValueOf(predicate)
+		if val.Kind() != reflect.Func {
+			return BadConvertFuncNotFunc
+		}
+		if val.Type().NumIn() != 2 {
+			return BadConvertFuncInCount
+		}
+		if val.Type().NumOut() != 1 || val.Type().Out(0).Kind() != reflect.Bool {
+			return BadConvertFuncOut
+		}
+		rules.conditions = append(rules.conditions, conditionRule{srcPath: srcPath, predicate: val})
+		return nil
+	}
+}
+
+// OptionFieldMapping declare field mapping rules in code for the struct type pair of src and dest,
+// for types without convertor tag such as generated protobuf struct, e.g.
+//
+//	OptionFieldMapping(Src{}, Dest{}, MapField("Foo", "Bar"), MapField("Addr.City", "City"), IgnoreDestField("Baz"))
+//
+// the rules work for pointers of the types too, it is concurrent unsafe
+func OptionFieldMapping(src, dest interface{}, rules ...FieldRule) Option {
+	return func(opts *Options) error {
+		key := [2]reflect.Type{indirectType(reflect.TypeOf(src)), indirectType(reflect.TypeOf(dest))}
+		if key[0] == nil || key[1] == nil || key[0].Kind() != reflect.Struct || key[1].Kind() != reflect.Struct {
+			return ErrFieldMappingNotStruct
+		}
+		if opts.fieldRules == nil {
+			opts.fieldRules = map[[2]reflect.Type]*pairRules{}
+		}
+		pr := opts.fieldRules[key]
+		if pr == nil {
+			pr = &pairRules{srcType: key[0]}
+			opts.fieldRules[key] = pr
+		}
+		for _, rule := range rules {
+			if err := rule(pr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+type fieldPair struct {
+	src, dest typeField
+}
+
+// pairFields is the fields of a struct type pair after applying field rules
+type pairFields struct {
+	err        error
+	src        []typeField // sorted fields matched by name
+	dest       []typeField
+	mapped     []fieldPair // fields matched explicitly
+	computed   []computedFields
+	conditions map[string][]reflect.Value // src field name or * -> predicates
+}
+
+type computedFields struct {
+	src  []typeField
+	dest []typeField
+	fn   reflect.Value
+}
+
+// getPairFields return nil if there is no rule for the type pair and no field named by path or alias
+func (c *convertor) getPairFields(srcType, destType reflect.Type, srcStruct, destStruct *typeStruct) *pairFields {
+	var rules *pairRules
+	if len(c.opts.fieldRules) > 0 {
+		rules = c.opts.fieldRules[[2]reflect.Type{srcType, destType}]
+	}
+	if rules == nil && !srcStruct.hasPath && !destStruct.hasPath && !srcStruct.hasAlias && !destStruct.hasAlias {
+		return nil
+	}
+	key := [2]*typeStruct{srcStruct, destStruct}
+	if val, ok := c.pairCache.Load(key); ok {
+		return val.(*pairFields)
+	}
+	pair, err := c.newPairFields(rules, srcStruct, destStruct)
+	if err != nil {
+		pair = &pairFields{err: err}
+	}
+	c.pairCache.Store(key, pair)
+	return pair
+}
+
+func (c *convertor) newPairFields(rules *pairRules, srcStruct, destStruct *typeStruct) (*pairFields, error) {
+	pair := &pairFields{}
+	srcMatched := map[string]bool{}
+	destMatched := map[string]bool{}
+	if rules == nil {
+		rules = &pairRules{}
+	}
+	for _, name := range rules.ignoreSrc {
+		field, err := c.cfg.resolvePath(srcStruct, name)
+		if err != nil {
+			return nil, err
+		}
+		srcMatched[field.Name] = true
+	}
+	for _, name := range rules.ignoreDest {
+		field, err := c.destCfg.resolvePath(destStruct, name)
+		if err != nil {
+			return nil, err
+		}
+		destMatched[field.Name] = true
+	}
+	for _, m := range rules.maps {
+		srcField, err := c.cfg.resolvePath(srcStruct, m[0])
+		if err != nil {
+			return nil, err
+		}
+		destField, err := c.destCfg.resolvePath(destStruct, m[1])
+		if err != nil {
+			return nil, err
+		}
+		srcMatched[topFieldName(srcField.Name)] = true
+		destMatched[topFieldName(destField.Name)] = true
+		pair.mapped = append(pair.mapped, fieldPair{src: srcField, dest: destField})
+	}
+	for _, rule := range rules.computes {
+		computed := computedFields{fn: rule.fn}
+		for i, path := range rule.srcPaths {
+			srcField, err := c.cfg.resolvePath(srcStruct, path)
+			if err != nil {
+				return nil, err
+			}
+			if in := rule.fn.Type().In(i); !srcField.Type.AssignableTo(in) && !indirectType(srcField.Type).AssignableTo(in) {
+				return nil, fmt.Errorf("compute func %v can't receive field %s(%v)", rule.fn.Type(), path, srcField.Type)
+			}
+			srcMatched[topFieldName(srcField.Name)] = true
+			computed.src = append(computed.src, srcField)
+		}
+		for _, path := range rule.destPaths {
+			destField, err := c.destCfg.resolvePath(destStruct, path)
+			if err != nil {
+				return nil, err
+			}
+			destMatched[topFieldName(destField.Name)] = true
+			computed.dest = append(computed.dest, destField)
+		}
+		pair.computed = append(pair.computed, computed)
+	}
+	for _, rule := range rules.conditions {
+		name := rule.srcPath
+		if name != "*" {
+			srcField, err := c.cfg.resolvePath(srcStruct, name)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkConditionValue(rule, srcField); err != nil {
+				return nil, err
+			}
+			name = srcField.Name
+		} else {
+			for _, srcField := range srcStruct.fields {
+				if err := checkConditionValue(rule, srcField); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if rule.predicate.Type().In(0) != rules.srcType {
+			return nil, fmt.Errorf("condition func %v of field %s should receive %v", rule.predicate.Type(), rule.srcPath, rules.srcType)
+		}
+		if pair.conditions == nil {
+			pair.conditions = map[string][]reflect.Value{}
+		}
+		pair.conditions[name] = append(pair.conditions[name], rule.predicate)
+	}
+	// dest field named by path reads from nested src field
+	destPathPairs, err := c.matchPathFields(destStruct, srcStruct, destMatched, srcMatched)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range destPathPairs {
+		pair.mapped = append(pair.mapped, fieldPair{src: p.dest, dest: p.src})
+	}
+	// src field named by path writes into nested dest field
+	srcPathPairs, err := c.matchPathFields(srcStruct, destStruct, srcMatched, destMatched)
+	if err != nil {
+		return nil, err
+	}
+	pair.mapped = append(pair.mapped, srcPathPairs...)
+	// fields with alias names
+	destAliasPairs, err := matchAliasFields(destStruct, srcStruct, destMatched, srcMatched)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range destAliasPairs {
+		pair.mapped = append(pair.mapped, fieldPair{src: p.dest, dest: p.src})
+	}
+	srcAliasPairs, err := matchAliasFields(srcStruct, destStruct, srcMatched, destMatched)
+	if err != nil {
+		return nil, err
+	}
+	pair.mapped = append(pair.mapped, srcAliasPairs...)
+	pair.src = unmatchedFields(srcStruct.fields, srcMatched)
+	pair.dest = unmatchedFields(destStruct.fields, destMatched)
+	return pair, nil
+}
+
+// matchPathFields match fields of s named by path like Address.City with the nested field of other struct,
+// the top field of other struct like Address is matched if s has no field with the same name,
+// the returned pairs' src is the field of s
+func (c *convertor) matchPathFields(s, other *typeStruct, matched, otherMatched map[string]bool) ([]fieldPair, error) {
+	if !s.hasPath {
+		return nil, nil
+	}
+	var pairs []fieldPair
+	for _, field := range s.fields {
+		if !strings.Contains(field.Name, ".") || matched[field.Name] {
+			continue
+		}
+		if _, ok := other.lookup(field.Name); ok { // both named by the same path
+			continue
+		}
+		top := topFieldName(field.Name)
+		if _, ok := other.lookup(top); !ok { // report missing field later
+			continue
+		}
+		otherField, err := c.cfg.resolvePath(other, field.Name)
+		if err != nil {
+			return nil, err
+		}
+		matched[field.Name] = true
+		if _, ok := s.lookup(top); !ok {
+			otherMatched[top] = true
+		}
+		pairs = append(pairs, fieldPair{src: field, dest: otherField})
+	}
+	return pairs, nil
+}
+
+// matchAliasFields match fields of s with aliases to the field of other struct with any of the names,
+// names are tried in order, it returns error if several fields of other struct have the names,
+// the returned pairs' src is the field of s
+func matchAliasFields(s, other *typeStruct, matched, otherMatched map[string]bool) ([]fieldPair, error) {
+	if !s.hasAlias {
+		return nil, nil
+	}
+	otherFields := map[string]int{} // name or alias -> index of other.fields
+	for k, field := range other.fields {
+		otherFields[field.Name] = k
+		for _, alias := range field.Aliases {
+			otherFields[alias] = k
+		}
+	}
+	var pairs []fieldPair
+	for _, field := range s.fields {
+		if len(field.Aliases) == 0 || matched[field.Name] {
+			continue
+		}
+		found := -1
+		for _, name := range append([]string{field.Name}, field.Aliases...) {
+			k, ok := otherFields[name]
+			if !ok || otherMatched[other.fields[k].Name] {
+				continue
+			}
+			if found >= 0 && found != k {
+				return nil, fmt.Errorf("%w %s: both %s and %s exist", ErrAmbiguousField, strings.Join(append([]string{field.Name}, field.Aliases...), "|"), other.fields[found].Name, other.fields[k].Name)
+			}
+			found = k
+		}
+		if found < 0 { // report missing field later
+			continue
+		}
+		matched[field.Name] = true
+		otherMatched[other.fields[found].Name] = true
+		pairs = append(pairs, fieldPair{src: field, dest: other.fields[found]})
+	}
+	return pairs, nil
+}
+
+func topFieldName(path string) string {
+	if i := strings.IndexByte(path, '.'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+func unmatchedFields(fields []typeField, matched map[string]bool) []typeField {
+	res := make([]typeField, 0, len(fields))
+	for _, field := range fields {
+		if !matched[field.Name] {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// resolvePath find the field of path like Addr.City in field tree of s,
+// the returned field walks through every struct on the path by NextStruct and NextIdx
+func (cfg *structConfig) resolvePath(s *typeStruct, path string) (typeField, error) {
+	var hops []typeField
+	var origins []string
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		segments[i] = cfg.fieldName(segment)
+		if s.err != nil {
+			return typeField{}, s.err
+		}
+		field, ok := s.lookup(segments[i])
+		if !ok {
+			return typeField{}, fmt.Errorf("field path %s not found", path)
+		}
+		hops = append(hops, fieldHops(field)...)
+		origins = append(origins, fieldPath(field))
+		if i < len(segments)-1 && indirectType(field.Type).Kind() != reflect.Struct {
+			return typeField{}, fmt.Errorf("field path %s: %s(%v) is not struct", path, segment, field.Type)
+		}
+		s = field.FinalStruct
+	}
+	field := chainHops(hops, strings.Join(segments, "."))
+	field.Origin = strings.Join(origins, ".")
+	return field, nil
+}
+
+// fieldHops split a field to the struct fields it walks through
+func fieldHops(field typeField) []typeField {
+	var hops []typeField
+	for {
+		hop := field
+		hop.NextStruct = nil
+		hop.NextIdx = -1
+		hops = append(hops, hop)
+		if field.NextStruct == nil {
+			return hops
+		}
+		field = field.NextStruct.fields[field.NextIdx]
+	}
+}
+
+// chainHops link hops to a field by single field structs, it has the type of the last hop
+func chainHops(hops []typeField, name string) typeField {
+	last := hops[len(hops)-1]
+	field := last
+	field.Name = name
+	for i := len(hops) - 2; i >= 0; i-- {
+		field = typeField{
+			Type:         last.Type,
+			Name:         name,
+			Idx:          hops[i].Idx,
+			NextIdx:      0,
+			NextStruct:   &typeStruct{fields: []typeField{field}},
+			FinalStruct:  last.FinalStruct,
+			fieldOptions: last.fieldOptions,
+		}
+	}
+	return field
+}
+
+// checkConditionValue check the field value is received by the predicate like the src values of compute func
+func checkConditionValue(rule conditionRule, srcField typeField) error {
+	if in := rule.predicate.Type().In(1); !srcField.Type.AssignableTo(in) && !indirectType(srcField.Type).AssignableTo(in) {
+		return fmt.Errorf("condition func %v can't receive field %s(%v)", rule.predicate.Type(), fieldPath(srcField), srcField.Type)
+	}
+	return nil
+}
+
+// convertComputed call compute func with src fields and convert its results to dest fields
+func (c *convertor) convertComputed(src, dest reflect.Value, computed computedFields) error {
+	in := make([]reflect.Value, len(computed.src))
+	for i, field := range computed.src {
+		inType := computed.fn.Type().In(i)
+		val, _, err := getValueByPath(src, field)
+		if err != nil {
+			return err
+		}
+		if isNilValue(val) {
+			val = reflect.Zero(inType)
+		} else if !val.Type().AssignableTo(inType) {
+			val = val.Elem()
+		}
+		in[i] = val
+	}
+	out, err := callFunc(computed.fn, in)
+	if err != nil {
+		return err
+	}
+	if err, ok := out[len(out)-1].Interface().(error); ok {
+		return err
+	}
+	for i, field := range computed.dest {
+		if (out[i].Kind() == reflect.Ptr || out[i].Kind() == reflect.Interface) && out[i].IsNil() {
+			continue
+		}
+		if err := c.setValueByPath(dest, out[i], field, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allow return whether src field should be converted by predicates of FieldCondition, panic of predicate is returned
+func (pair *pairFields) allow(src reflect.Value, field typeField) (bool, error) {
+	if pair == nil || len(pair.conditions) == 0 {
+		return true, nil
+	}
+	predicates := slices.Concat(pair.conditions["*"], pair.conditions[field.Name])
+	if len(predicates) == 0 {
+		return true, nil
+	}
+	src = reflect.Indirect(src)
+	val, _, err := getValueByPath(src, field)
+	if err != nil {
+		return false, err
+	}
+	for _, predicate := range predicates {
+		valType := predicate.Type().In(1)
+		arg := val
+		if arg != zeroValue && !arg.Type().AssignableTo(valType) && arg.Kind() == reflect.Ptr && !arg.IsNil() {
+			arg = arg.Elem()
+		}
+		if arg == zeroValue || !arg.Type().AssignableTo(valType) {
+			arg = reflect.Zero(valType)
+		}
+		out, err := callFunc(predicate, []reflect.Value{src, arg})
+		if err != nil {
+			return false, err
+		}
+		if !out[0].Bool() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,217 @@
+package convertor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldMapping(t *testing.T) {
+	type Address struct {
+		City   string
+		Street string
+	}
+	type Src struct {
+		Foo  int
+		Bar  string
+		Addr *Address
+		Tmp  string
+	}
+	type Dest struct {
+		Bar     int64
+		Foo     string
+		City    string
+		Baz     float64
+		Address struct {
+			Street *string
+		}
+	}
+	c, err := NewConvertor(OptionFieldMapping(Src{}, &Dest{},
+		MapField("Foo", "Bar"),
+		MapField("Bar", "Foo"),
+		MapField("Addr.City", "City"),
+		MapField("Addr.Street", "Address.Street"),
+		IgnoreSrcField("Tmp"),
+		IgnoreDestField("Baz"),
+	))
+	assert.Nil(t, err)
+	var d Dest
+	err = c.Convert(&Src{Foo: 1, Bar: "bar", Addr: &Address{City: "city", Street: "street"}, Tmp: "tmp"}, &d)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, d.Bar)
+	assert.Equal(t, "bar", d.Foo)
+	assert.Equal(t, "city", d.City)
+	assert.Equal(t, "street", *d.Address.Street)
+	assert.Equal(t, 0.0, d.Baz)
+
+	// nil struct on the path is skipped
+	d = Dest{}
+	err = c.Convert(Src{Foo: 2}, &d)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, d.Bar)
+	assert.Nil(t, d.Address.Street)
+
+	// rules of other type pairs don't work
+	err = c.Convert(struct{ Foo int }{}, &d)
+	assert.EqualError(t, err, "Address: src has no field Address(struct { Street *string }) convert to dest")
+
+	_, err = NewConvertor(OptionFieldMapping(1, Dest{}))
+	assert.Equal(t, ErrFieldMappingNotStruct, err)
+	err = Convert(Src{}, &d, OptionFieldMapping(Src{}, Dest{}, MapField("Addr.Zip", "City")))
+	assert.EqualError(t, err, "field path Addr.Zip not found")
+	err = Convert(Src{}, &d, OptionFieldMapping(Src{}, Dest{}, MapField("Foo.Zip", "City")))
+	assert.EqualError(t, err, "field path Foo.Zip: Foo(int) is not struct")
+}
+
+func TestPathTag(t *testing.T) {
+	type Address struct {
+		City   string
+		Street string
+	}
+	type User struct {
+		Name    string
+		Address *Address
+	}
+	type UserRow struct {
+		Name          string
+		AddressCity   string  `convertor:"Address.City"`
+		AddressStreet *string `convertor:"Address.Street"`
+	}
+	var row UserRow
+	err := Convert(User{Name: "name", Address: &Address{City: "city", Street: "street"}}, &row)
+	assert.Nil(t, err)
+	assert.Equal(t, "name", row.Name)
+	assert.Equal(t, "city", row.AddressCity)
+	assert.Equal(t, "street", *row.AddressStreet)
+
+	var user User
+	err = Convert(row, &user)
+	assert.Nil(t, err)
+	assert.Equal(t, User{Name: "name", Address: &Address{City: "city", Street: "street"}}, user)
+
+	// nil struct on the path is skipped
+	row = UserRow{}
+	err = Convert(User{Name: "name"}, &row)
+	assert.Nil(t, err)
+	assert.Equal(t, UserRow{Name: "name"}, row)
+
+	// flatten struct with path field
+	type Wrapper struct {
+		UserRow `convertor:"+"`
+	}
+	var w Wrapper
+	err = Convert(User{Address: &Address{City: "city"}}, &w)
+	assert.Nil(t, err)
+	assert.Equal(t, "city", w.AddressCity)
+
+	type NoAddress struct {
+		Name string
+	}
+	err = Convert(NoAddress{}, &row)
+	assert.EqualError(t, err, "AddressCity: src has no field Address.City(string) convert to dest")
+	type BadAddress struct {
+		Name    string
+		Address string
+	}
+	err = Convert(BadAddress{}, &row)
+	assert.EqualError(t, err, "field path Address.City: Address(string) is not struct")
+}
+
+func TestComputeField(t *testing.T) {
+	type Money struct {
+		Amount   int64
+		Currency string
+	}
+	type Person struct {
+		FirstName string
+		LastName  *string
+		Price     Money
+		Age       int
+	}
+	type PersonDTO struct {
+		FullName string
+		Amount   int32
+		Currency *string
+		Age      int
+	}
+	c, err := NewConvertor(OptionFieldMapping(Person{}, PersonDTO{},
+		ComputeField([]string{"FirstName", "LastName"}, []string{"FullName"}, func(first, last string) (string, error) {
+			return first + " " + last, nil
+		}),
+		ComputeField([]string{"Price"}, []string{"Amount", "Currency"}, func(m Money) (int64, string, error) {
+			if m.Amount < 0 {
+				return 0, "", errors.New("negative amount")
+			}
+			return m.Amount, m.Currency, nil
+		}),
+	))
+	assert.Nil(t, err)
+	last := "last"
+	var dto PersonDTO
+	err = c.Convert(Person{FirstName: "first", LastName: &last, Price: Money{Amount: 100, Currency: "USD"}, Age: 10}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, "first last", dto.FullName)
+	assert.EqualValues(t, 100, dto.Amount)
+	assert.Equal(t, "USD", *dto.Currency)
+	assert.Equal(t, 10, dto.Age)
+
+	dto = PersonDTO{}
+	err = c.Convert(Person{FirstName: "first"}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, "first ", dto.FullName)
+	err = c.Convert(Person{Price: Money{Amount: -1}}, &dto)
+	assert.EqualError(t, err, "Amount: negative amount")
+
+	_, err = NewConvertor(OptionFieldMapping(Person{}, PersonDTO{},
+		ComputeField([]string{"FirstName"}, []string{"FullName"}, func(first string) string { return first })))
+	assert.Equal(t, BadConvertFuncOut, err)
+	err = Convert(Person{}, &dto, OptionFieldMapping(Person{}, PersonDTO{},
+		ComputeField([]string{"Age"}, []string{"FullName"}, func(first string) (string, error) { return first, nil })))
+	assert.EqualError(t, err, "compute func func(string) (string, error) can't receive field Age(int)")
+}
+
+func TestFieldCondition(t *testing.T) {
+	type User struct {
+		Name     string
+		Nick     *string
+		Email    string
+		Verified bool
+	}
+	type UserDTO struct {
+		Name     string
+		Nick     string
+		Email    string
+		Verified bool
+	}
+	c, err := NewConvertor(OptionFieldMapping(User{}, UserDTO{},
+		FieldCondition("Email", func(src User, email string) bool {
+			return src.Verified
+		}),
+		FieldCondition("*", func(src User, value interface{}) bool {
+			s, ok := value.(string)
+			return !ok || s != ""
+		}),
+	))
+	assert.Nil(t, err)
+	dto := UserDTO{Name: "old name", Nick: "old nick", Email: "old email"}
+	err = c.Convert(&User{Name: "", Email: "email"}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Name: "old name", Nick: "old nick", Email: "old email"}, dto)
+	nick := "nick"
+	err = c.Convert(User{Name: "name", Nick: &nick, Email: "email", Verified: true}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Name: "name", Nick: "nick", Email: "email", Verified: true}, dto)
+
+	err = Convert(User{}, &dto, OptionFieldMapping(User{}, UserDTO{},
+		FieldCondition("Email", func(src UserDTO, email string) bool { return true })))
+	assert.Contains(t, err.Error(), "should receive convertor.User")
+	_, err = NewConvertor(OptionFieldMapping(User{}, UserDTO{}, FieldCondition("Email", func(src User) bool { return true })))
+	assert.Equal(t, BadConvertFuncInCount, err)
+	err = Convert(User{}, &dto, OptionFieldMapping(User{}, UserDTO{},
+		FieldCondition("Email", func(src User, email int) bool { return true })))
+	assert.EqualError(t, err, "condition func func(convertor.User, int) bool can't receive field Email(string)")
+	err = Convert(User{}, &dto, OptionFieldMapping(User{}, UserDTO{},
+		FieldCondition("*", func(src User, value string) bool { return true })))
+	assert.EqualError(t, err, "condition func func(convertor.User, string) bool can't receive field Verified(bool)")
+}
@@ -0,0 +1,66 @@
+package convertor
+
+import (
+	"reflect"
+	"sync"
+)
+
+const (
+	convertFromMethod = "ConvertFrom"
+	convertToMethod   = "ConvertTo"
+)
+
+var methodCache sync.Map // [2]reflect.Type -> methodResolution
+
+type methodResolution struct {
+	method reflect.Value // func of the method, receiver is the first param
+	from   bool          // ConvertFrom of dest, otherwise ConvertTo of src
+	ok     bool
+}
+
+// getConvertMethod find conversion method of type pair, destType is pointer:
+//
+//	func (d *DestType) ConvertFrom(src SrcType) error
+//	func (s SrcType) ConvertTo(dest *DestType) error
+//
+// ConvertFrom is prior to ConvertTo, ConvertTo must be in the method set of SrcType rather than *SrcType
+func getConvertMethod(srcType, destType reflect.Type) methodResolution {
+	// most types have no methods, skip the cache lookup
+	if srcType.NumMethod() == 0 && destType.NumMethod() == 0 {
+		return methodResolution{}
+	}
+	key := [2]reflect.Type{srcType, destType}
+	if val, ok := methodCache.Load(key); ok {
+		return val.(methodResolution)
+	}
+	var resolution methodResolution
+	if m, ok := destType.MethodByName(convertFromMethod); ok && isConvertMethod(m.Type) && srcType.AssignableTo(m.Type.In(1)) {
+		resolution = methodResolution{method: m.Func, from: true, ok: true}
+	} else if srcType.Kind() != reflect.Interface {
+		if m, ok := srcType.MethodByName(convertToMethod); ok && isConvertMethod(m.Type) && m.Type.In(1) == destType {
+			resolution = methodResolution{method: m.Func, ok: true}
+		}
+	}
+	methodCache.Store(key, resolution)
+	return resolution
+}
+
+// isConvertMethod check method type with receiver is like func(T, P) error
+func isConvertMethod(typ reflect.Type) bool {
+	return typ.NumIn() == 2 && typ.NumOut() == 1 && isErrorType(typ.Out(0))
+}
+
+func (resolution methodResolution) call(src, dest reflect.Value) error {
+	in := []reflect.Value{src, dest}
+	if resolution.from {
+		in = []reflect.Value{dest, src}
+	}
+	out, err := callFunc(resolution.method, in)
+	if err != nil {
+		return err
+	}
+	if err, ok := out[0].Interface().(error); ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package convertor
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MoneyDTO struct {
+	Text string
+}
+
+func (d *MoneyDTO) ConvertFrom(src Money) error {
+	if src.Cents < 0 {
+		return errors.New("negative money")
+	}
+	d.Text = strconv.FormatInt(src.Cents/100, 10) + "." + strconv.FormatInt(src.Cents%100, 10)
+	return nil
+}
+
+type Money struct {
+	Cents int64
+}
+
+func (m Money) ConvertTo(dest *int64) error {
+	*dest = m.Cents
+	return nil
+}
+
+type Version struct {
+	Major int
+	Minor int
+	Note  string
+}
+
+type VersionDTO struct {
+	Major int
+	Minor int
+	Label string
+}
+
+var versionConvertor, _ = NewConvertor(OptionSrcNotExistFieldIgnore(), OptionDestNotExistFieldIgnore())
+
+// ConvertTo converts the same fields by default and fills the others
+func (v Version) ConvertTo(dest *VersionDTO) error {
+	if err := ConvertDefault(versionConvertor, v, dest); err != nil {
+		return err
+	}
+	dest.Label = "v" + strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+	return nil
+}
+
+func TestConvertMethod(t *testing.T) {
+	type Order struct {
+		Price   Money
+		Amount  Money
+		Version Version
+	}
+	type OrderDTO struct {
+		Price   MoneyDTO
+		Amount  int64
+		Version VersionDTO
+	}
+	var dto OrderDTO
+	err := Convert(Order{Price: Money{Cents: 1234}, Amount: Money{Cents: 5}, Version: Version{Major: 1, Minor: 2}}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, OrderDTO{Price: MoneyDTO{Text: "12.34"}, Amount: 5, Version: VersionDTO{Major: 1, Minor: 2, Label: "v1.2"}}, dto)
+
+	err = Convert(Order{Price: Money{Cents: -1}}, &dto)
+	assert.EqualError(t, err, "Price: negative money")
+
+	// registered convert func is prior to methods
+	c, err := NewConvertor(OptionConvertFunc(func(src Money, dest *MoneyDTO) error {
+		dest.Text = "func"
+		return nil
+	}))
+	assert.Nil(t, err)
+	err = c.Convert(Order{}, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, "func", dto.Price.Text)
+
+	report, err := Check(reflect.TypeOf(Order{}), reflect.TypeOf(OrderDTO{}))
+	assert.Nil(t, err)
+	assert.Equal(t, MappingMethod, report.Mappings[0].Kind)
+	assert.Equal(t, MappingMethod, report.Mappings[1].Kind)
+	assert.Equal(t, MappingMethod, report.Mappings[2].Kind)
+
+	// method of different type pair is ignored
+	type Other struct {
+		Cents int64
+	}
+	err = Convert(Other{Cents: 1}, &MoneyDTO{})
+	assert.True(t, errors.Is(err, ErrDestFieldMissing))
+}
@@ -0,0 +1,124 @@
+package convertor
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy maps a go field name to the name used to match fields,
+// names from convertor tag are used as they are
+type NamingStrategy func(name string) string
+
+var (
+	// SnakeCase maps UserID to user_id
+	SnakeCase NamingStrategy = func(name string) string {
+		return joinWords(splitWords(name), "_")
+	}
+	// KebabCase maps UserID to user-id
+	KebabCase NamingStrategy = func(name string) string {
+		return joinWords(splitWords(name), "-")
+	}
+	// CamelCase maps UserID to userId
+	CamelCase NamingStrategy = func(name string) string {
+		words := splitWords(name)
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				word = strings.ToUpper(word[:1]) + word[1:]
+			}
+			words[i] = word
+		}
+		return strings.Join(words, "")
+	}
+)
+
+// OptionNamingStrategy map go field names by s before matching,
+// e.g. with SnakeCase field UserName matches a field with tag `convertor:"user_name"`
+func OptionNamingStrategy(s NamingStrategy) Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().naming = s
+		return nil
+	}
+}
+
+// OptionTrimFieldName strip the first matched prefix and suffix from go field names before matching,
+// e.g. with suffix DTO field UserDTO matches field User, a name is never stripped to empty
+func OptionTrimFieldName(prefixes, suffixes []string) Option {
+	return func(opts *Options) error {
+		cfg := opts.getStructOptions()
+		cfg.trimPrefixes = append(cfg.trimPrefixes, prefixes...)
+		cfg.trimSuffixes = append(cfg.trimSuffixes, suffixes...)
+		return nil
+	}
+}
+
+// isBuiltinNaming return whether s is SnakeCase, KebabCase or CamelCase, they capture nothing and share parsed structs
+func isBuiltinNaming(s NamingStrategy) bool {
+	ptr := reflect.ValueOf(s).Pointer()
+	for _, builtin := range []NamingStrategy{SnakeCase, KebabCase, CamelCase} {
+		if ptr == reflect.ValueOf(builtin).Pointer() {
+			return true
+		}
+	}
+	return false
+}
+
+// goFieldName maps go field name by trim config and naming strategy
+func (cfg *structConfig) goFieldName(name string) string {
+	for _, prefix := range cfg.trimPrefixes {
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	for _, suffix := range cfg.trimSuffixes {
+		if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	if cfg.naming != nil {
+		name = cfg.naming(name)
+	}
+	return name
+}
+
+// splitWords split go name to words, HTTPServerID is split to HTTP Server ID
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '_' && !isWordStart(runes, i) {
+			continue
+		}
+		if start < i {
+			words = append(words, string(runes[start:i]))
+		}
+		start = i
+		if i < len(runes) && runes[i] == '_' {
+			start++
+		}
+	}
+	return words
+}
+
+func isWordStart(runes []rune, i int) bool {
+	prev, cur := runes[i-1], runes[i]
+	if prev == '_' {
+		return true
+	}
+	if !unicode.IsUpper(cur) {
+		return false
+	}
+	// lower to upper: userID, or the last upper of an acronym: HTTPServer
+	return !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+}
+
+func joinWords(words []string, sep string) string {
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
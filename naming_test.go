@@ -0,0 +1,83 @@
+package convertor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamingStrategy(t *testing.T) {
+	for name, expect := range map[string][3]string{
+		"UserID":     {"user_id", "user-id", "userId"},
+		"HTTPServer": {"http_server", "http-server", "httpServer"},
+		"ID":         {"id", "id", "id"},
+		"Name":       {"name", "name", "name"},
+		"User_Name":  {"user_name", "user-name", "userName"},
+		"Address2":   {"address2", "address2", "address2"},
+	} {
+		assert.Equal(t, expect, [3]string{SnakeCase(name), KebabCase(name), CamelCase(name)}, name)
+	}
+
+	type Model struct {
+		UserName string `convertor:"user_name"`
+		UserID   int    `convertor:"user_id"`
+		Age      int
+	}
+	type DTO struct {
+		UserNameDTO string
+		UserID      int64
+		PbAge       int
+	}
+	var d DTO
+	err := Convert(Model{UserName: "name", UserID: 1, Age: 2}, &d,
+		OptionNamingStrategy(SnakeCase),
+		OptionTrimFieldName([]string{"Pb"}, []string{"DTO"}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, DTO{UserNameDTO: "name", UserID: 1, PbAge: 2}, d)
+
+	// the strategy decides the field tree, the same type is parsed again by another strategy
+	c, err := NewConvertor(OptionNamingStrategy(func(name string) string {
+		return "user_" + strings.ToLower(name)
+	}))
+	assert.Nil(t, err)
+	type Custom struct {
+		Name string
+		ID   int
+	}
+	type Target struct {
+		UserName string `convertor:"user_name"`
+		UserID   int    `convertor:"user_id"`
+	}
+	var target Target
+	err = c.Convert(Custom{Name: "custom", ID: 3}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{UserName: "custom", UserID: 3}, target)
+	err = Convert(Custom{}, &target, OptionNamingStrategy(SnakeCase))
+	assert.EqualError(t, err, "ID: dest has no field to receive src field id(int)")
+}
+
+func TestNamingStrategyClosure(t *testing.T) {
+	mkNaming := func(prefix string) NamingStrategy {
+		return func(name string) string {
+			return prefix + name
+		}
+	}
+	type Src struct {
+		A int
+	}
+	type Dest struct {
+		X int `convertor:"xA"`
+		Y int `convertor:"yA"`
+	}
+	var d Dest
+	err := Convert(Src{A: 1}, &d, OptionNamingStrategy(mkNaming("x")), OptionSrcNotExistFieldIgnore())
+	assert.Nil(t, err)
+	assert.Equal(t, Dest{X: 1}, d)
+	// closure of the same func literal parses structs by its own captured prefix
+	d = Dest{}
+	err = Convert(Src{A: 2}, &d, OptionNamingStrategy(mkNaming("y")), OptionSrcNotExistFieldIgnore())
+	assert.Nil(t, err)
+	assert.Equal(t, Dest{Y: 2}, d)
+}
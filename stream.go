@@ -0,0 +1,71 @@
+package convertor
+
+import (
+	"iter"
+	"reflect"
+)
+
+// ConvertSeq converts every element of seq to a D value with c, the struct
+// metadata of S and D is looked up once and reused for all elements.
+// A failed element yields the zero D with the error, the iteration goes on
+// until seq is exhausted or the consumer stops.
+// DefaultConvertor is used if c is nil.
+func ConvertSeq[S, D any](c Convertor, seq iter.Seq[S]) iter.Seq2[D, error] {
+	convert := seqConvertFunc[S, D](c)
+	return func(yield func(D, error) bool) {
+		for src := range seq {
+			var dest D
+			err := convert(src, &dest)
+			if err != nil {
+				dest = *new(D) // drop the partially converted value
+			}
+			if !yield(dest, err) {
+				return
+			}
+		}
+	}
+}
+
+// ConvertSeq2 is like ConvertSeq, but the source sequence carries errors too,
+// such as a database cursor, an element with non-nil error is passed through
+// without conversion.
+func ConvertSeq2[S, D any](c Convertor, seq iter.Seq2[S, error]) iter.Seq2[D, error] {
+	convert := seqConvertFunc[S, D](c)
+	return func(yield func(D, error) bool) {
+		for src, err := range seq {
+			var dest D
+			if err == nil {
+				if err = convert(src, &dest); err != nil {
+					dest = *new(D) // drop the partially converted value
+				}
+			}
+			if !yield(dest, err) {
+				return
+			}
+		}
+	}
+}
+
+func seqConvertFunc[S, D any](c Convertor) func(src S, dest *D) error {
+	if c == nil {
+		c = DefaultConvertor
+	}
+	cv, ok := c.(*convertor)
+	if !ok {
+		return func(src S, dest *D) error {
+			return c.Convert(src, dest)
+		}
+	}
+	srcType := reflect.TypeOf((*S)(nil)).Elem()
+	destType := reflect.TypeOf((*D)(nil))
+	if srcType.Kind() == reflect.Interface { // dynamic type is only known per element
+		return func(src S, dest *D) error {
+			return cv.Convert(src, dest)
+		}
+	}
+	srcStruct := cv.cfg.getCacheStruct(srcType, nil)
+	destStruct := cv.destCfg.getCacheStruct(destType, nil)
+	return func(src S, dest *D) error {
+		return cv.convertValue(reflect.ValueOf(&src).Elem(), reflect.ValueOf(dest), srcStruct, destStruct, cv.convert)
+	}
+}
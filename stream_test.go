@@ -0,0 +1,65 @@
+package convertor
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertSeq(t *testing.T) {
+	type Row struct {
+		ID   int
+		Name string
+	}
+	type DTO struct {
+		ID   int64
+		Name *string
+	}
+	rows := []Row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	var dtos []DTO
+	for dto, err := range ConvertSeq[Row, DTO](nil, slices.Values(rows)) {
+		assert.Nil(t, err)
+		dtos = append(dtos, dto)
+	}
+	assert.Equal(t, len(rows), len(dtos))
+	for i := range rows {
+		assert.EqualValues(t, rows[i].ID, dtos[i].ID)
+		assert.Equal(t, rows[i].Name, *dtos[i].Name)
+	}
+
+	// stop early
+	var n int
+	for range ConvertSeq[*Row, DTO](DefaultConvertor, func(yield func(*Row) bool) {
+		for i := range rows {
+			if !yield(&rows[i]) {
+				return
+			}
+		}
+	}) {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+
+	// errors of element and source
+	type BadDTO struct {
+		ID int64
+	}
+	for dto, err := range ConvertSeq[Row, BadDTO](nil, slices.Values(rows[:1])) {
+		assert.EqualError(t, err, "Name: dest has no field to receive src field Name(string)")
+		assert.Zero(t, dto)
+	}
+	errSource := errors.New("source error")
+	var errs []error
+	for dto, err := range ConvertSeq2[Row, DTO](nil, func(yield func(Row, error) bool) {
+		_ = yield(rows[0], nil) && yield(Row{}, errSource)
+	}) {
+		if err == nil {
+			assert.EqualValues(t, 1, dto.ID)
+		}
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []error{nil, errSource}, errs)
+}
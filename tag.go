@@ -0,0 +1,199 @@
+package convertor
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fieldTag is a parsed tag like `convertor:"Name,omitempty"`
+type fieldTag struct {
+	name    string   // empty name means using field name
+	options []string // comma separated options after name
+}
+
+// OptionTagKey look up field tag by keys in order instead of convertor tag,
+// e.g. OptionTagKey("mapper", "convertor", "json", "db") use the first tag found,
+// options after comma like json's omitempty are not part of the name
+func OptionTagKey(keys ...string) Option {
+	return func(opts *Options) error {
+		opts.getStructOptions().tagKeys = keys
+		return nil
+	}
+}
+
+// lookupTag return the first tag of tag keys for the profile of cfg,
+// options profile, from and to are handled here and not returned
+func (cfg *structConfig) lookupTag(field reflect.StructField) (tag fieldTag, ok bool) {
+	keys := cfg.tagKeys
+	if len(keys) == 0 {
+		keys = []string{convertorTag}
+	}
+	for _, key := range keys {
+		value, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(value, ",")
+		tag := fieldTag{name: parts[0]}
+		if strings.Contains(tag.name, "=") && !strings.HasPrefix(tag.name, "+") { // options only like `convertor:"from=Foo,to=Bar"`
+			tag.name = ""
+		} else {
+			parts = parts[1:]
+		}
+		inProfile := true
+		for _, option := range parts {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "profile":
+				inProfile = slices.Contains(strings.Split(value, "|"), cfg.profile)
+			case "from": // name when converting from another struct
+				if cfg.dest {
+					tag.name = value
+				}
+			case "to": // name when converting to another struct
+				if !cfg.dest {
+					tag.name = value
+				}
+			default:
+				tag.options = append(tag.options, option)
+			}
+		}
+		if inProfile {
+			return tag, true
+		}
+	}
+	return fieldTag{}, false
+}
+
+// parseFlattenPrefix parse prefix of flatten tag like `convertor:"+prefix=Billing"`
+func parseFlattenPrefix(name string) (string, error) {
+	name = strings.TrimPrefix(name, "+")
+	if name == "" {
+		return "", nil
+	}
+	prefix, ok := strings.CutPrefix(name, "prefix=")
+	if !ok || prefix == "" {
+		return "", fmt.Errorf("bad flatten tag: +%s", name)
+	}
+	return prefix, nil
+}
+
+// prefixedName return the name of sub field of a flatten struct with prefix
+func (cfg *structConfig) prefixedName(prefix, name string) string {
+	if cfg.naming != nil { // join as go name, then map the whole name, e.g. Billing and city is billing_city by SnakeCase
+		name = cfg.naming(prefix + strings.ToUpper(name[:1]) + name[1:])
+	} else {
+		name = prefix + name
+	}
+	return cfg.fieldName(name)
+}
+
+// fieldOptions is the options of field tag like `convertor:"Name,required,default=1,omitempty,using=name"`
+type fieldOptions struct {
+	Required  bool          // return error if src field is missing, nil or zero
+	OmitEmpty bool          // skip zero src field
+	Default   reflect.Value // fill dest field if src field is missing or nil
+	Using     reflect.Value // named convert func
+}
+
+// usingFunc return the named convert func of dest field or src field
+func usingFunc(srcField, destField typeField) reflect.Value {
+	if destField.Using.IsValid() {
+		return destField.Using
+	}
+	return srcField.Using
+}
+
+// parseOptions parse tag options, unknown options like json's string are ignored
+func (cfg *structConfig) parseOptions(tf *typeField, options []string) error {
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			tf.Required = true
+		case "omitempty":
+			tf.OmitEmpty = true
+		case "default":
+			val, err := parseDefault(tf.Type, value)
+			if err != nil {
+				return fmt.Errorf("bad default value %q of field %s(%v): %v", value, tf.Name, tf.Type, err)
+			}
+			tf.Default = val
+		case "using":
+			using, err := cfg.namedConvertFunc(value, tf.Type)
+			if err != nil {
+				return fmt.Errorf("field %s(%v): %v", tf.Name, tf.Type, err)
+			}
+			tf.Using = using
+		}
+	}
+	return nil
+}
+
+// namedConvertFunc find named convert func which can convert field of typ
+func (cfg *structConfig) namedConvertFunc(name string, typ reflect.Type) (reflect.Value, error) {
+	f, ok := cfg.namedFuncs[name]
+	if !ok {
+		f, ok = namedConvertFuncs[name]
+	}
+	if !ok {
+		return zeroValue, fmt.Errorf("convert func %s not found", name)
+	}
+	typ = indirectType(typ)
+	srcType, destType := convertFuncTypes(f.Type())
+	if cfg.dest && destType != reflect.PtrTo(typ) || !cfg.dest && !typ.AssignableTo(srcType) {
+		return zeroValue, fmt.Errorf("convert func %s(%v) doesn't fit", name, f.Type())
+	}
+	return f, nil
+}
+
+// parseDefault parse default value of basic kind or pointer of basic kind
+func parseDefault(typ reflect.Type, value string) (reflect.Value, error) {
+	typ = indirectType(typ)
+	val := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		val.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return zeroValue, err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return zeroValue, err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return zeroValue, err
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return zeroValue, err
+		}
+		val.SetFloat(f)
+	default:
+		return zeroValue, fmt.Errorf("default value of %s is not supported", typ.Kind())
+	}
+	return val, nil
+}
+
+// checkRequired return error if src or dest field of a missing, nil or zero src value is required
+func checkRequired(srcField, destField typeField) error {
+	if destField.Required {
+		return fmt.Errorf("required field %s(%v) is empty", destField.Name, destField.Type)
+	}
+	if srcField.Required {
+		return fmt.Errorf("required field %s(%v) is empty", srcField.Name, srcField.Type)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package convertor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagKey(t *testing.T) {
+	type External struct {
+		ID       int    `json:"id" db:"user_id"`
+		Name     string `json:"name,omitempty"`
+		Password string `json:"-"`
+		Email    string `json:",omitempty"`
+		Extra    string `db:"extra"`
+	}
+	type Internal struct {
+		UserID int    `mapper:"id"`
+		Name   string `mapper:"name" convertor:"-"`
+		Email  string
+		Extra  string `json:"-" convertor:"extra"`
+	}
+	var in Internal
+	err := Convert(External{ID: 1, Name: "name", Password: "xxx", Email: "email", Extra: "extra"}, &in,
+		OptionTagKey("mapper", "convertor", "json", "db"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, Internal{UserID: 1, Name: "name", Email: "email", Extra: "extra"}, in)
+
+	err = Convert(External{}, &in, OptionTagKey("db"))
+	assert.EqualError(t, err, "Extra: src has no field Extra(string) convert to dest")
+}
+
+func TestTagOptions(t *testing.T) {
+	type Src struct {
+		Name  *string
+		Note  string
+		Count int
+	}
+	type Dest struct {
+		Name    string  `convertor:"Name,required"`
+		Note    *string `convertor:"Note,omitempty"`
+		Count   int     `convertor:",default=1"`
+		Retries *int    `convertor:"Retries,default=3"`
+	}
+	name := "name"
+	var d Dest
+	err := Convert(Src{Name: &name, Count: 5}, &d)
+	assert.Nil(t, err)
+	assert.Equal(t, "name", d.Name)
+	assert.Nil(t, d.Note)
+	assert.Equal(t, 5, d.Count)
+	assert.Equal(t, 3, *d.Retries)
+
+	err = Convert(Src{}, &d)
+	assert.EqualError(t, err, "Name: required field Name(string) is empty")
+	empty := ""
+	err = Convert(Src{Name: &empty}, &d)
+	assert.EqualError(t, err, "Name: required field Name(string) is empty")
+
+	type PtrSrc struct {
+		Name  string
+		Count *int
+	}
+	d = Dest{}
+	err = Convert(PtrSrc{Name: "name"}, &d, OptionSrcNotExistFieldIgnore())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, d.Count)
+	err = Convert(struct{ Count int }{}, &d, OptionSrcNotExistFieldIgnore())
+	assert.EqualError(t, err, "Name: src has no field Name(string) convert to dest")
+
+	// options of flatten field and src field
+	type Wrapper struct {
+		Dest `convertor:"+"`
+	}
+	var w Wrapper
+	err = Convert(PtrSrc{Name: "name"}, &w, OptionSrcNotExistFieldIgnore())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, *w.Retries)
+	type RequiredSrc struct {
+		Name string `convertor:",required"`
+	}
+	err = Convert(RequiredSrc{}, &struct{ Name string }{})
+	assert.EqualError(t, err, "Name: required field Name(string) is empty")
+
+	type BadDefault struct {
+		Count int `convertor:",default=x"`
+	}
+	err = Convert(struct{ Count int }{}, &BadDefault{})
+	assert.EqualError(t, err, `bad default value "x" of field Count(int): strconv.ParseInt: parsing "x": invalid syntax`)
+}
+
+func TestProfileAndDirectionTag(t *testing.T) {
+	type User struct {
+		ID       int
+		Name     string `convertor:"FullName,profile=public|admin"`
+		Password string `convertor:"-,profile=public"`
+		Email    string `convertor:"to=Mail,from=EmailAddress"`
+	}
+	type PublicView struct {
+		ID       int
+		FullName string
+		Mail     string
+	}
+	type StorageView struct {
+		ID       int
+		Name     string
+		Password string
+		Mail     string
+	}
+	var pv PublicView
+	err := Convert(User{ID: 1, Name: "name", Password: "xxx", Email: "email"}, &pv, OptionProfile("public"))
+	assert.Nil(t, err)
+	assert.Equal(t, PublicView{ID: 1, FullName: "name", Mail: "email"}, pv)
+
+	var sv StorageView
+	err = Convert(User{ID: 1, Name: "name", Password: "xxx", Email: "email"}, &sv)
+	assert.Nil(t, err)
+	assert.Equal(t, StorageView{ID: 1, Name: "name", Password: "xxx", Mail: "email"}, sv)
+	err = Convert(User{}, &pv)
+	assert.EqualError(t, err, "FullName: src has no field FullName(string) convert to dest")
+
+	// from= names the field when User is dest
+	type Input struct {
+		ID           int
+		Name         string
+		Password     string
+		EmailAddress string
+	}
+	var u User
+	err = Convert(Input{ID: 2, Name: "name", Password: "xxx", EmailAddress: "email"}, &u)
+	assert.Nil(t, err)
+	assert.Equal(t, User{ID: 2, Name: "name", Password: "xxx", Email: "email"}, u)
+}
+
+func TestNamedConvertFunc(t *testing.T) {
+	RegisterNamedConvertFunc("unixSeconds", func(src time.Time, dest *int64) error {
+		*dest = src.Unix()
+		return nil
+	})
+	type Model struct {
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	type Row struct {
+		CreatedAt int64 `convertor:"CreatedAt,using=unixSeconds"`
+		UpdatedAt int64 `convertor:",using=unixMillis"`
+	}
+	now := time.Unix(1600000000, 123000000)
+	var row Row
+	err := Convert(Model{CreatedAt: now, UpdatedAt: now}, &row, OptionNamedConvertFunc("unixMillis", func(src time.Time, dest *int64) error {
+		*dest = src.UnixMilli()
+		return nil
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, Row{CreatedAt: 1600000000, UpdatedAt: 1600000000123}, row)
+
+	err = Convert(Model{}, &row)
+	assert.EqualError(t, err, "field UpdatedAt(int64): convert func unixMillis not found")
+	type BadRow struct {
+		CreatedAt int32 `convertor:",using=unixSeconds"`
+	}
+	err = Convert(Model{}, &BadRow{}, OptionDestNotExistFieldIgnore())
+	assert.EqualError(t, err, "field CreatedAt(int32): convert func unixSeconds(func(time.Time, *int64) error) doesn't fit")
+
+	// named func on src field
+	type SrcModel struct {
+		CreatedAt time.Time `convertor:",using=unixSeconds"`
+	}
+	var seconds struct {
+		CreatedAt int64
+	}
+	err = Convert(SrcModel{CreatedAt: now}, &seconds)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1600000000, seconds.CreatedAt)
+	var int32Row struct {
+		CreatedAt int32
+	}
+	err = Convert(SrcModel{CreatedAt: now}, &int32Row)
+	assert.EqualError(t, err, "CreatedAt: convert func func(time.Time, *int64) error can't convert time.Time to *int32")
+
+	// closures of the same func literal run with their own captured values
+	offsetFunc := func(offset int64) Option {
+		return OptionNamedConvertFunc("offset", func(src time.Time, dest *int64) error {
+			*dest = src.Unix() + offset
+			return nil
+		})
+	}
+	type OffsetRow struct {
+		CreatedAt int64 `convertor:",using=offset"`
+	}
+	var offsetRow OffsetRow
+	err = Convert(SrcModel{CreatedAt: now}, &offsetRow, offsetFunc(1))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1600000001, offsetRow.CreatedAt)
+	err = Convert(SrcModel{CreatedAt: now}, &offsetRow, offsetFunc(2))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1600000002, offsetRow.CreatedAt)
+}
+
+func TestFlattenPrefix(t *testing.T) {
+	type Address struct {
+		City   string
+		Street string `convertor:"street"`
+	}
+	type Order struct {
+		ID       int
+		Billing  Address `convertor:"+prefix=Billing"`
+		Shipping Address `convertor:"+prefix=Shipping"`
+	}
+	type OrderRow struct {
+		ID             int
+		BillingCity    string
+		Billingstreet  string
+		ShippingCity   string
+		Shippingstreet string
+	}
+	order := Order{
+		ID:       1,
+		Billing:  Address{City: "b city", Street: "b street"},
+		Shipping: Address{City: "s city", Street: "s street"},
+	}
+	var row OrderRow
+	err := Convert(order, &row)
+	assert.Nil(t, err)
+	assert.Equal(t, OrderRow{ID: 1, BillingCity: "b city", Billingstreet: "b street", ShippingCity: "s city", Shippingstreet: "s street"}, row)
+	var back Order
+	err = Convert(row, &back)
+	assert.Nil(t, err)
+	assert.Equal(t, order, back)
+
+	// prefix is joined as go name before naming strategy
+	type SnakeRow struct {
+		ID             int    `convertor:"id"`
+		BillingCity    string `convertor:"billing_city"`
+		BillingStreet  string `convertor:"billing_street"`
+		ShippingCity   string `convertor:"shipping_city"`
+		ShippingStreet string `convertor:"shipping_street"`
+	}
+	var snake SnakeRow
+	err = Convert(order, &snake, OptionNamingStrategy(SnakeCase))
+	assert.Nil(t, err)
+	assert.Equal(t, SnakeRow{ID: 1, BillingCity: "b city", BillingStreet: "b street", ShippingCity: "s city", ShippingStreet: "s street"}, snake)
+
+	type Ambiguous struct {
+		Billing  Address `convertor:"+"`
+		Shipping Address `convertor:"+"`
+	}
+	err = Convert(Ambiguous{}, &row)
+	assert.EqualError(t, err, "ambiguous field City")
+	type BadPrefix struct {
+		Billing Address `convertor:"+Billing"`
+	}
+	err = Convert(BadPrefix{}, &row)
+	assert.EqualError(t, err, "bad flatten tag: +Billing")
+}
+
+func TestAliasTag(t *testing.T) {
+	type OldUser struct {
+		ID       int
+		Username string
+	}
+	type User struct {
+		ID    int
+		Login string `convertor:"Login|Username"`
+	}
+	type NewUser struct {
+		ID    int
+		Login string
+	}
+	var u User
+	err := Convert(OldUser{ID: 1, Username: "old"}, &u)
+	assert.Nil(t, err)
+	assert.Equal(t, User{ID: 1, Login: "old"}, u)
+	err = Convert(NewUser{ID: 2, Login: "new"}, &u)
+	assert.Nil(t, err)
+	assert.Equal(t, User{ID: 2, Login: "new"}, u)
+
+	// src field feeds dest with old name
+	var old OldUser
+	err = Convert(User{ID: 3, Login: "login"}, &old)
+	assert.Nil(t, err)
+	assert.Equal(t, OldUser{ID: 3, Username: "login"}, old)
+
+	type BothUser struct {
+		ID       int
+		Login    string
+		Username string
+	}
+	err = Convert(BothUser{}, &u)
+	assert.EqualError(t, err, "ambiguous field Login|Username: both Login and Username exist")
+	type ConflictUser struct {
+		Login    string `convertor:"Login|Username"`
+		Username string
+	}
+	err = Convert(ConflictUser{}, &u)
+	assert.EqualError(t, err, "conflict field name and tag: Username")
+	err = Convert(struct{ ID int }{}, &u)
+	assert.EqualError(t, err, "Login: src has no field Login(string) convert to dest")
+}